@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"dynamic-ui-backend/internal/services"
+)
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	version := fs.String("version", "v1", "schema version to export")
+	out := fs.String("out", "", "output archive path")
+	fs.Parse(args)
+
+	if *out == "" {
+		return fmt.Errorf("-out is required")
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest, err := services.NewArchiveService(services.NewUIService()).Export(*version, f)
+	if err != nil {
+		os.Remove(*out)
+		return err
+	}
+
+	fmt.Printf("Exported %d files for %s to %s\n", len(manifest.Files), *version, *out)
+	return nil
+}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	version := fs.String("version", "v1", "schema version to import into")
+	in := fs.String("in", "", "archive path")
+	dryRun := fs.Bool("dry-run", false, "report changes without writing drafts")
+	fs.Parse(args)
+
+	if *in == "" {
+		return fmt.Errorf("-in is required")
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		return err
+	}
+
+	report, err := services.NewArchiveService(services.NewUIService()).Import(*version, data, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
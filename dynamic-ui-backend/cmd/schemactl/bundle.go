@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"dynamic-ui-backend/internal/services"
+)
+
+func runBundle(args []string) error {
+	fs := flag.NewFlagSet("bundle", flag.ExitOnError)
+	version := fs.String("version", "v1", "schema version to bundle")
+	out := fs.String("out", "", "output bundle path")
+	fs.Parse(args)
+
+	if *out == "" {
+		return fmt.Errorf("-out is required")
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest, err := services.NewBundleService(services.NewUIService()).Write(*version, f)
+	if err != nil {
+		os.Remove(*out)
+		return err
+	}
+
+	for _, missing := range manifest.MissingImages {
+		fmt.Fprintf(os.Stderr, "Warning: referenced image not found: %s\n", missing)
+	}
+	fmt.Printf("Bundled %d files for %s to %s\n", len(manifest.Files), *version, *out)
+	fmt.Printf("Bundle hash: %s\n", manifest.BundleHash)
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+)
+
+func runKeygen(args []string) error {
+	fs := flag.NewFlagSet("keygen", flag.ExitOnError)
+	kid := fs.String("kid", "", "key id, e.g. 2026-10")
+	fs.Parse(args)
+
+	if *kid == "" {
+		return fmt.Errorf("-kid is required")
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("# Add to SCHEMA_SIGNING_KEYS (keep secret):")
+	fmt.Printf("%s:%s\n", *kid, base64.StdEncoding.EncodeToString(priv.Seed()))
+	fmt.Println("# Public key (add to SCHEMA_VERIFY_KEYS once the key is retired):")
+	fmt.Printf("%s:%s\n", *kid, base64.StdEncoding.EncodeToString(pub))
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"dynamic-ui-backend/internal/services"
+
+	"github.com/joho/godotenv"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"bundle":   {usage: "bundle -version v1 -out bundle.zip", run: runBundle},
+	"export":   {usage: "export -version v1 -out schemas.zip", run: runExport},
+	"keygen":   {usage: "keygen -kid 2026-10", run: runKeygen},
+	"migrate":  {usage: "migrate -version v1 [-source published|drafts|revisions | -dir path] [-to 2] [-force] [-dry-run] [-list]", run: runMigrate},
+	"import":   {usage: "import -version v1 -in schemas.zip [-dry-run]", run: runImport},
+	"validate": {usage: "validate -version v1 [-screen home | -file screen.json]", run: runValidate},
+}
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found")
+	}
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", os.Args[1])
+		printUsage()
+		os.Exit(1)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	names := services.SortedKeys(commands)
+
+	fmt.Fprintln(os.Stderr, "Usage: schemactl <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"dynamic-ui-backend/internal/schemamigrate"
+	"dynamic-ui-backend/internal/services"
+)
+
+// migrateTarget is one document to migrate and where its result goes.
+type migrateTarget struct {
+	name  string
+	data  []byte
+	write func(data []byte) error
+}
+
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	version := flags.String("version", "v1", "schema version to migrate")
+	source := flags.String("source", "published", "documents to migrate: published (written as drafts), drafts or revisions")
+	dir := flags.String("dir", "", "migrate every .json file under this directory in place instead")
+	to := flags.Int("to", schemamigrate.LatestFormat(), "target format version")
+	dryRun := flags.Bool("dry-run", false, "report changes without writing")
+	force := flags.Bool("force", false, "with -source published, overwrite pending drafts too")
+	list := flags.Bool("list", false, "list the registered transforms and exit")
+	flags.Parse(args)
+
+	if *list {
+		for _, t := range schemamigrate.Transforms {
+			fmt.Printf("%d -> %d  %s: %s\n", t.From, t.From+1, t.Name, t.Description)
+		}
+		return nil
+	}
+
+	var targets []migrateTarget
+	var err error
+	if *dir != "" {
+		targets, err = dirTargets(*dir)
+	} else {
+		var skipped []string
+		targets, skipped, err = sourceTargets(services.NewUIService(), *version, *source, *force)
+		if len(skipped) > 0 {
+			fmt.Fprintf(os.Stderr, "Skipped %d screens with a pending draft (migrate them with -source drafts, or pass -force to overwrite):\n", len(skipped))
+			for _, name := range skipped {
+				fmt.Fprintf(os.Stderr, "  %s\n", name)
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	migrated, failed := 0, 0
+	for _, t := range targets {
+		out, changes, err := schemamigrate.MigrateBytes(t.data, *to)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", t.name, err)
+			failed++
+			continue
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		fmt.Println(t.name)
+		for _, c := range changes {
+			fmt.Printf("  ~ %s: %s -> %s  (%s)\n", c.Path, formatValue(c.Before), formatValue(c.After), c.Transform)
+		}
+		migrated++
+
+		if !*dryRun {
+			if err := t.write(out); err != nil {
+				return fmt.Errorf("%s: %w", t.name, err)
+			}
+		}
+	}
+
+	verb := "Migrated"
+	if *dryRun {
+		verb = "Would migrate"
+	}
+	fmt.Printf("%s %d of %d documents to format %d\n", verb, migrated, len(targets), *to)
+	if failed > 0 {
+		return fmt.Errorf("%d documents could not be migrated", failed)
+	}
+	return nil
+}
+
+// sourceTargets returns the documents of a version to migrate. Migrating
+// published documents writes drafts, so screens that already have a draft
+// are skipped and returned separately unless force is set.
+func sourceTargets(uiService *services.UIService, version, source string, force bool) ([]migrateTarget, []string, error) {
+	switch source {
+	case "published", "drafts":
+		files, err := uiService.PublishedFiles(version)
+		if source == "drafts" {
+			files, err = uiService.DraftFiles(version)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		targets := make([]migrateTarget, 0, len(files))
+		skipped := make([]string, 0)
+		for _, f := range files {
+			path := f.Path
+			if source == "published" && !force {
+				draft, err := uiService.ReadDraft(version, path)
+				if err != nil {
+					return nil, nil, err
+				}
+				if draft != nil {
+					skipped = append(skipped, path)
+					continue
+				}
+			}
+			// Published documents are never rewritten directly: the migrated
+			// copy becomes a draft and goes through the normal publish flow.
+			targets = append(targets, migrateTarget{
+				name: path,
+				data: f.Data,
+				write: func(data []byte) error {
+					return uiService.WriteDraft(version, path, data)
+				},
+			})
+		}
+		return targets, skipped, nil
+	case "revisions":
+		dir, err := uiService.RevisionsDir(version)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			return []migrateTarget{}, nil, nil
+		}
+		targets, err := dirTargets(dir)
+		return targets, nil, err
+	}
+	return nil, nil, fmt.Errorf("unknown source '%s'", source)
+}
+
+func dirTargets(dir string) ([]migrateTarget, error) {
+	targets := make([]migrateTarget, 0)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, migrateTarget{
+			name:  path,
+			data:  data,
+			write: func(data []byte) error { return services.WriteFileAtomic(path, data) },
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].name < targets[j].name })
+	return targets, nil
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/internal/validation"
+)
+
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	version := fs.String("version", "v1", "schema version to validate")
+	screen := fs.String("screen", "", "validate only this published screen")
+	file := fs.String("file", "", "validate a schema file instead of published screens")
+	fs.Parse(args)
+
+	// Asset references are checked against the registry, so validation
+	// needs the database.
+	db, err := database.NewDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	svc := services.NewValidationService(services.NewUIService(), repositories.NewAssetRepository(db))
+
+	results := make(map[string][]validation.Issue)
+	if *file != "" {
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("%s: %w", *file, err)
+		}
+		if results[*file], err = svc.Validate(doc); err != nil {
+			return err
+		}
+	} else {
+		if results, err = svc.ValidateVersion(*version, *screen); err != nil {
+			return err
+		}
+	}
+
+	return reportIssues(results)
+}
+
+// reportIssues prints issues grouped by document and fails when any of them
+// is an error.
+func reportIssues(results map[string][]validation.Issue) error {
+	failed := false
+	for _, name := range services.SortedKeys(results) {
+		issues := results[name]
+		if len(issues) == 0 {
+			fmt.Printf("✓ %s\n", name)
+			continue
+		}
+		fmt.Printf("✗ %s\n", name)
+		for _, issue := range issues {
+			fmt.Printf("    %-7s %-20s %s: %s\n", issue.Severity, issue.Rule, issue.Path, issue.Message)
+		}
+		if validation.HasErrors(issues) {
+			failed = true
+		}
+	}
+	if failed {
+		return fmt.Errorf("validation failed")
+	}
+	return nil
+}
@@ -12,7 +12,9 @@ import (
 
 	"dynamic-ui-backend/internal/api"
 	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/repositories"
 	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/internal/signing"
 	"dynamic-ui-backend/pkg/logger"
 
 	"github.com/joho/godotenv"
@@ -43,8 +45,29 @@ func main() {
 	uiService := services.NewUIService()
 	appLogger.Info("✅ UI Service initialized")
 
+	keyRing, err := signing.LoadFromEnv()
+	if err != nil {
+		appLogger.Fatal(fmt.Sprintf("Schema signing keys invalid: %v", err))
+	}
+	if keyRing.Enabled() {
+		appLogger.Info("✅ Schema signing enabled")
+	}
+
+	submissionGuard, err := services.NewSubmissionGuard(repositories.NewBlockedSubmitterRepository(db))
+	if err != nil {
+		appLogger.Fatal(fmt.Sprintf("Submission guard config invalid: %v", err))
+	}
+
+	trashService, err := services.NewTrashService(repositories.NewCategoryRepository(db), repositories.NewBrandRepository(db))
+	if err != nil {
+		appLogger.Fatal(fmt.Sprintf("Trash config invalid: %v", err))
+	}
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	defer stopPurge()
+	go purgeTrash(purgeCtx, trashService, appLogger)
+
 	// Routes
-	router := api.SetupRoutes(db, uiService, appLogger)
+	router := api.SetupRoutes(db, uiService, keyRing, submissionGuard, trashService, appLogger)
 
 	port := getEnv("SERVER_PORT", "8080")
 	host := getEnv("SERVER_HOST", "0.0.0.0")
@@ -84,6 +107,25 @@ func main() {
 	appLogger.Info("✅ Stopped gracefully")
 }
 
+// purgeTrash removes expired trash entries at startup and then hourly.
+func purgeTrash(ctx context.Context, trash *services.TrashService, log *logger.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		purged, err := trash.PurgeExpired()
+		if err != nil {
+			log.Errorw("Trash purge failed", "purged", purged, "error", err)
+		} else if purged > 0 {
+			log.Infow("Trash purged", "purged", purged)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"crypto/md5"
+	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,6 +18,7 @@ import (
 	"dynamic-ui-backend/internal/auth"
 	"dynamic-ui-backend/internal/models"
 	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/internal/services"
 	"dynamic-ui-backend/pkg/logger"
 
 	"github.com/gorilla/mux"
@@ -77,6 +80,21 @@ func (h *AdminHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Optional parent for subcategories
+	var parentID *int
+	if parentStr := r.FormValue("parent_id"); parentStr != "" {
+		id, err := strconv.Atoi(parentStr)
+		if err != nil {
+			h.respondError(w, "Invalid parent_id", http.StatusBadRequest)
+			return
+		}
+		if _, err := h.categoryRepo.GetByID(id); err != nil {
+			h.respondError(w, "Parent category not found", http.StatusBadRequest)
+			return
+		}
+		parentID = &id
+	}
+
 	// Handle file upload
 	file, header, err := r.FormFile("image")
 	var imageURL string
@@ -104,6 +122,7 @@ func (h *AdminHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 
 	// Create category
 	req := &models.CreateCategoryRequest{
+		ParentID:     parentID,
 		Name:         name,
 		SearchText:   searchText,
 		ImageURL:     imageURL,
@@ -298,14 +317,190 @@ func (h *AdminHandler) UpdateBrand(w http.ResponseWriter, r *http.Request) {
 	h.respondSuccess(w, brand)
 }
 
-// Read operations (no changes needed)
+// Read operations
+//
+// GetAllCategories lists the active categories for the app; see
+// listQueryFromRequest for filtering, sorting and paging. With ?nested=true
+// the categories are nested under their parents instead, and ?root=<id>
+// returns that category with its subtree; the tree is never filtered or
+// paged, so nested cannot be combined with the list parameters.
 func (h *AdminHandler) GetAllCategories(w http.ResponseWriter, r *http.Request) {
-	categories, err := h.categoryRepo.GetAll()
+	h.listCategories(w, r, true)
+}
+
+// ListCategories is GetAllCategories for admins, which also lists inactive
+// categories unless ?is_active= is given.
+func (h *AdminHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	h.listCategories(w, r, false)
+}
+
+func (h *AdminHandler) listCategories(w http.ResponseWriter, r *http.Request, public bool) {
+	if r.URL.Query().Get("nested") == "true" {
+		for _, param := range listParams {
+			if r.URL.Query().Has(param) {
+				h.respondError(w, fmt.Sprintf("nested cannot be combined with %s", param), http.StatusBadRequest)
+				return
+			}
+		}
+		h.getNestedCategories(w, r, public)
+		return
+	}
+
+	q, err := listQueryFromRequest(r, public)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	categories, meta, err := h.categoryRepo.List(q)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidListQuery) {
+			h.respondError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.respondError(w, "Failed to get categories", http.StatusInternalServerError)
+		return
+	}
+	h.respondList(w, categories, meta)
+}
+
+func (h *AdminHandler) getNestedCategories(w http.ResponseWriter, r *http.Request, public bool) {
+	categories, err := h.categoryRepo.GetAll(!public)
 	if err != nil {
 		h.respondError(w, "Failed to get categories", http.StatusInternalServerError)
 		return
 	}
-	h.respondSuccess(w, categories)
+
+	tree := services.BuildCategoryTree(categories)
+	rootStr := r.URL.Query().Get("root")
+	if rootStr == "" {
+		h.respondSuccess(w, tree)
+		return
+	}
+	root, err := strconv.Atoi(rootStr)
+	if err != nil {
+		h.respondError(w, "Invalid root", http.StatusBadRequest)
+		return
+	}
+	node, ok := services.FindCategory(tree, root)
+	if !ok {
+		h.respondError(w, "Category not found", http.StatusNotFound)
+		return
+	}
+	h.respondSuccess(w, node)
+}
+
+// GetCategoryTree returns every category nested under its parent, inactive
+// ones included so they can still be moved or reactivated.
+func (h *AdminHandler) GetCategoryTree(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.categoryRepo.GetAll(true)
+	if err != nil {
+		h.respondError(w, "Failed to get categories", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, services.BuildCategoryTree(categories))
+}
+
+// GetCategorySubtree returns a category with all its subcategories, inactive
+// ones included.
+func (h *AdminHandler) GetCategorySubtree(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	categories, err := h.categoryRepo.GetAll(true)
+	if err != nil {
+		h.respondError(w, "Failed to get categories", http.StatusInternalServerError)
+		return
+	}
+	node, ok := services.FindCategory(services.BuildCategoryTree(categories), id)
+	if !ok {
+		h.respondError(w, "Category not found", http.StatusNotFound)
+		return
+	}
+	h.respondSuccess(w, node)
+}
+
+// MoveCategory moves a category under another parent.
+// Body: {"parent_id": 12, "display_order": 3}; a null parent_id moves it to
+// the top level.
+func (h *AdminHandler) MoveCategory(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.MoveCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	category, err := h.categoryRepo.Move(id, req.ParentID, req.DisplayOrder, claims.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			h.respondError(w, "Category not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Errorw("Failed to move category", "id", id, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondSuccess(w, category)
+	h.logger.Infow("Category moved", "id", id, "parent_id", category.ParentID, "by", claims.Username)
+}
+
+// GetCategoryOrder returns the order of the children of ?parent_id=<id>, or
+// of the top-level categories, with the fingerprint to reorder them.
+func (h *AdminHandler) GetCategoryOrder(w http.ResponseWriter, r *http.Request) {
+	var parentID *int
+	if parentStr := r.URL.Query().Get("parent_id"); parentStr != "" {
+		id, err := strconv.Atoi(parentStr)
+		if err != nil {
+			h.respondError(w, "Invalid parent_id", http.StatusBadRequest)
+			return
+		}
+		if _, err := h.categoryRepo.GetByID(id); err != nil {
+			h.respondError(w, "Parent category not found", http.StatusNotFound)
+			return
+		}
+		parentID = &id
+	}
+
+	order, err := h.categoryRepo.GetOrder(parentID)
+	if err != nil {
+		h.respondError(w, "Failed to get category order", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, order)
+}
+
+// ReorderCategories sets the order of all children of a parent at once.
+// Body: {"parent_id": null, "ids": [3, 1, 2], "fingerprint": "..."}.
+func (h *AdminHandler) ReorderCategories(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+
+	var req models.ReorderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if req.Fingerprint == "" {
+		h.respondError(w, "fingerprint is required", http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.categoryRepo.Reorder(req.ParentID, req.IDs, req.Fingerprint, claims.UserID)
+	if err != nil {
+		h.respondReorderError(w, err)
+		return
+	}
+
+	h.respondSuccess(w, order)
+	h.logger.Infow("Categories reordered", "parent_id", req.ParentID, "count", len(order.IDs), "by", claims.Username)
 }
 
 func (h *AdminHandler) GetCategory(w http.ResponseWriter, r *http.Request) {
@@ -318,31 +513,118 @@ func (h *AdminHandler) GetCategory(w http.ResponseWriter, r *http.Request) {
 	h.respondSuccess(w, category)
 }
 
+// DeleteCategory moves a category to the trash, see TrashHandler.
 func (h *AdminHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
 	id, _ := strconv.Atoi(mux.Vars(r)["id"])
-	if err := h.categoryRepo.Delete(id); err != nil {
+	if err := h.categoryRepo.Delete(id, claims.UserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			h.respondError(w, "Category not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, repositories.ErrCategoryHasChildren) {
+			h.respondError(w, "Category has subcategories; move or delete them first", http.StatusConflict)
+			return
+		}
 		h.respondError(w, "Failed to delete category", http.StatusInternalServerError)
 		return
 	}
-	h.respondSuccess(w, map[string]string{"message": "Category deleted"})
+	h.respondSuccess(w, map[string]string{"message": "Category moved to trash"})
+	h.logger.Infow("Category deleted", "id", id, "by", claims.Username)
 }
 
+// GetAllBrands lists the active brands for the app; see
+// listQueryFromRequest for filtering, sorting and paging.
 func (h *AdminHandler) GetAllBrands(w http.ResponseWriter, r *http.Request) {
-	brands, err := h.brandRepo.GetAll()
+	h.listBrands(w, r, true)
+}
+
+// ListBrands is GetAllBrands for admins, which also lists inactive brands
+// unless ?is_active= is given.
+func (h *AdminHandler) ListBrands(w http.ResponseWriter, r *http.Request) {
+	h.listBrands(w, r, false)
+}
+
+func (h *AdminHandler) listBrands(w http.ResponseWriter, r *http.Request, public bool) {
+	q, err := listQueryFromRequest(r, public)
 	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	brands, meta, err := h.brandRepo.List(q)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidListQuery) {
+			h.respondError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.respondError(w, "Failed to get brands", http.StatusInternalServerError)
 		return
 	}
-	h.respondSuccess(w, brands)
+	h.respondList(w, brands, meta)
 }
 
+// GetBrandOrder returns the order of all brands with the fingerprint to
+// reorder them.
+func (h *AdminHandler) GetBrandOrder(w http.ResponseWriter, r *http.Request) {
+	order, err := h.brandRepo.GetOrder()
+	if err != nil {
+		h.respondError(w, "Failed to get brand order", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, order)
+}
+
+// ReorderBrands sets the order of all brands at once.
+// Body: {"ids": [3, 1, 2], "fingerprint": "..."}.
+func (h *AdminHandler) ReorderBrands(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+
+	var req models.ReorderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if req.Fingerprint == "" {
+		h.respondError(w, "fingerprint is required", http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.brandRepo.Reorder(req.IDs, req.Fingerprint, claims.UserID)
+	if err != nil {
+		h.respondReorderError(w, err)
+		return
+	}
+
+	h.respondSuccess(w, order)
+	h.logger.Infow("Brands reordered", "count", len(order.IDs), "by", claims.Username)
+}
+
+func (h *AdminHandler) respondReorderError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrOrderChanged):
+		h.respondError(w, "Order was changed by someone else; reload and try again", http.StatusConflict)
+	case errors.Is(err, repositories.ErrInvalidOrder):
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Errorw("Failed to reorder", "error", err)
+		h.respondError(w, "Failed to reorder", http.StatusInternalServerError)
+	}
+}
+
+// DeleteBrand moves a brand to the trash, see TrashHandler.
 func (h *AdminHandler) DeleteBrand(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
 	id, _ := strconv.Atoi(mux.Vars(r)["id"])
-	if err := h.brandRepo.Delete(id); err != nil {
+	if err := h.brandRepo.Delete(id, claims.UserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			h.respondError(w, "Brand not found", http.StatusNotFound)
+			return
+		}
 		h.respondError(w, "Failed to delete brand", http.StatusInternalServerError)
 		return
 	}
-	h.respondSuccess(w, map[string]string{"message": "Brand deleted"})
+	h.respondSuccess(w, map[string]string{"message": "Brand moved to trash"})
+	h.logger.Infow("Brand deleted", "id", id, "by", claims.Username)
 }
 
 // Helper methods
@@ -393,6 +675,18 @@ func (h *AdminHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
 	})
 }
 
+// respondList responds with a page of a list in data and its paging in
+// meta.
+func (h *AdminHandler) respondList(w http.ResponseWriter, data interface{}, meta *models.ListMeta) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+		"meta":    meta,
+	})
+}
+
 func (h *AdminHandler) respondError(w http.ResponseWriter, message string, status int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -401,3 +695,79 @@ func (h *AdminHandler) respondError(w http.ResponseWriter, message string, statu
 		Error:   message,
 	})
 }
+
+// listParams are the parameters read by listQueryFromRequest.
+var listParams = []string{"name", "is_active", "created_from", "created_to", "updated_from",
+	"updated_to", "created_by", "sort", "limit", "offset", "cursor"}
+
+// listQueryFromRequest reads the list parameters of the category and brand
+// endpoints:
+//
+//	name=sam                      name contains, case-insensitive
+//	is_active=true|false          admin only; public lists are always active
+//	created_from=, created_to=    YYYY-MM-DD (to inclusive) or RFC3339
+//	updated_from=, updated_to=
+//	created_by=<user id>
+//	sort=name | -created_at       display_order (default), name, created_at, updated_at, id
+//	limit=50                      1-500; without it every match is returned
+//	offset=100 | cursor=<next_cursor of the previous page>
+func listQueryFromRequest(r *http.Request, public bool) (*models.ListQuery, error) {
+	params := r.URL.Query()
+	q := &models.ListQuery{Name: params.Get("name"), Cursor: params.Get("cursor")}
+
+	if public {
+		active := true
+		q.IsActive = &active
+	} else if v := params.Get("is_active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("is_active must be true or false")
+		}
+		q.IsActive = &active
+	}
+
+	var err error
+	if q.CreatedFrom, err = parseDateParam(params.Get("created_from"), false); err != nil {
+		return nil, err
+	}
+	if q.CreatedTo, err = parseDateParam(params.Get("created_to"), true); err != nil {
+		return nil, err
+	}
+	if q.UpdatedFrom, err = parseDateParam(params.Get("updated_from"), false); err != nil {
+		return nil, err
+	}
+	if q.UpdatedTo, err = parseDateParam(params.Get("updated_to"), true); err != nil {
+		return nil, err
+	}
+	if v := params.Get("created_by"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid created_by")
+		}
+		q.CreatedBy = &id
+	}
+
+	q.Sort = params.Get("sort")
+	if strings.HasPrefix(q.Sort, "-") {
+		q.Sort, q.Desc = q.Sort[1:], true
+	}
+
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 500 {
+			return nil, fmt.Errorf("limit must be between 1 and 500")
+		}
+		q.Limit = n
+	}
+	if v := params.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("offset must not be negative")
+		}
+		q.Offset = n
+	}
+	if q.Offset > 0 && q.Cursor != "" {
+		return nil, fmt.Errorf("use either offset or cursor, not both")
+	}
+	return q, nil
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"dynamic-ui-backend/internal/auth"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+type AssetHandler struct {
+	assetRepo    *repositories.AssetRepository
+	assetService *services.AssetService
+	logger       *logger.Logger
+}
+
+func NewAssetHandler(assetRepo *repositories.AssetRepository, assetService *services.AssetService, log *logger.Logger) *AssetHandler {
+	return &AssetHandler{assetRepo: assetRepo, assetService: assetService, logger: log}
+}
+
+// UploadAsset registers a new version of a Lottie animation or SVG icon.
+// Form fields: name, kind (lottie|icon), file.
+func (h *AssetHandler) UploadAsset(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		h.respondError(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	name := r.FormValue("name")
+	kind := r.FormValue("kind")
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		h.respondError(w, "No file provided", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		h.respondError(w, "Failed to read file", http.StatusBadRequest)
+		return
+	}
+
+	asset, created, err := h.assetService.Register(name, kind, data, claims.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to register asset", "name", name, "kind", kind, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondSuccess(w, map[string]interface{}{"asset": asset, "created": created})
+	h.logger.Infow("Asset uploaded", "name", asset.Name, "kind", asset.Kind, "version", asset.Version, "created", created, "by", claims.Username)
+}
+
+// GetAssets lists the newest active version of every asset (?kind= filters).
+func (h *AssetHandler) GetAssets(w http.ResponseWriter, r *http.Request) {
+	assets, err := h.assetRepo.GetLatest(r.URL.Query().Get("kind"))
+	if err != nil {
+		h.respondError(w, "Failed to get assets", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, assets)
+}
+
+// GetAsset returns one asset, the newest active version unless ?version= is given.
+func (h *AssetHandler) GetAsset(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	version, _ := strconv.Atoi(r.URL.Query().Get("version"))
+
+	asset, err := h.assetRepo.Get(vars["kind"], vars["name"], version)
+	if err != nil {
+		h.respondError(w, "Asset not found", http.StatusNotFound)
+		return
+	}
+	h.respondSuccess(w, asset)
+}
+
+func (h *AssetHandler) GetAssetVersions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assets, err := h.assetRepo.GetVersions(vars["kind"], vars["name"])
+	if err != nil {
+		h.respondError(w, "Failed to get asset versions", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, assets)
+}
+
+// SetAssetActive enables or disables one version. Body: {"is_active": false}.
+func (h *AssetHandler) SetAssetActive(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	vars := mux.Vars(r)
+	version, err := strconv.Atoi(vars["version"])
+	if err != nil {
+		h.respondError(w, "Invalid version", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		IsActive *bool `json:"is_active"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.IsActive == nil {
+		h.respondError(w, "is_active is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.assetRepo.SetActive(vars["kind"], vars["name"], version, *req.IsActive); err != nil {
+		h.respondError(w, "Asset not found", http.StatusNotFound)
+		return
+	}
+
+	h.respondSuccess(w, map[string]string{"message": "Asset updated"})
+	h.logger.Infow("Asset updated", "name", vars["name"], "kind", vars["kind"], "version", version, "active", *req.IsActive, "by", claims.Username)
+}
+
+func (h *AssetHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *AssetHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"dynamic-ui-backend/internal/auth"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+type BrandCategoryHandler struct {
+	relationRepo *repositories.BrandCategoryRepository
+	categoryRepo *repositories.CategoryRepository
+	brandRepo    *repositories.BrandRepository
+	logger       *logger.Logger
+}
+
+func NewBrandCategoryHandler(relationRepo *repositories.BrandCategoryRepository, categoryRepo *repositories.CategoryRepository, brandRepo *repositories.BrandRepository, log *logger.Logger) *BrandCategoryHandler {
+	return &BrandCategoryHandler{relationRepo: relationRepo, categoryRepo: categoryRepo, brandRepo: brandRepo, logger: log}
+}
+
+// GetCategoryBrands lists the active brands under an active category.
+func (h *BrandCategoryHandler) GetCategoryBrands(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	category, err := h.categoryRepo.GetByID(id)
+	if err != nil || !category.IsActive {
+		h.respondError(w, "Category not found", http.StatusNotFound)
+		return
+	}
+
+	brands, err := h.relationRepo.GetBrands(id)
+	if err != nil {
+		h.respondError(w, "Failed to get brands", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, brands)
+}
+
+// GetBrandCategories lists the active categories an active brand is listed
+// under.
+func (h *BrandCategoryHandler) GetBrandCategories(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	brand, err := h.brandRepo.GetByID(id)
+	if err != nil || !brand.IsActive {
+		h.respondError(w, "Brand not found", http.StatusNotFound)
+		return
+	}
+
+	categories, err := h.relationRepo.GetCategories(id)
+	if err != nil {
+		h.respondError(w, "Failed to get categories", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, categories)
+}
+
+// AttachCategory lists a brand under a category, or reorders an existing
+// link. Body (optional): {"brand_order": 2, "category_order": 0}.
+func (h *BrandCategoryHandler) AttachCategory(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	brandID, categoryID, ok := h.relationIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.AttachBrandCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if _, err := h.brandRepo.GetByID(brandID); err != nil {
+		h.respondError(w, "Brand not found", http.StatusNotFound)
+		return
+	}
+	if _, err := h.categoryRepo.GetByID(categoryID); err != nil {
+		h.respondError(w, "Category not found", http.StatusNotFound)
+		return
+	}
+
+	relation, err := h.relationRepo.Attach(brandID, categoryID, &req, claims.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to attach brand to category", "brand_id", brandID, "category_id", categoryID, "error", err)
+		h.respondError(w, "Failed to attach brand to category", http.StatusInternalServerError)
+		return
+	}
+
+	h.respondSuccess(w, relation)
+	h.logger.Infow("Brand attached to category", "brand_id", brandID, "category_id", categoryID, "by", claims.Username)
+}
+
+// DetachCategory removes a brand from a category.
+func (h *BrandCategoryHandler) DetachCategory(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	brandID, categoryID, ok := h.relationIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.relationRepo.Detach(brandID, categoryID); err != nil {
+		h.respondError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	h.respondSuccess(w, map[string]string{"message": "Brand detached from category"})
+	h.logger.Infow("Brand detached from category", "brand_id", brandID, "category_id", categoryID, "by", claims.Username)
+}
+
+func (h *BrandCategoryHandler) relationIDs(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	vars := mux.Vars(r)
+	brandID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.respondError(w, "Invalid brand ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	categoryID, err := strconv.Atoi(vars["category_id"])
+	if err != nil {
+		h.respondError(w, "Invalid category ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return brandID, categoryID, true
+}
+
+func (h *BrandCategoryHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *BrandCategoryHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
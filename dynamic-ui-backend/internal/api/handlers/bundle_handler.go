@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+)
+
+type BundleHandler struct {
+	bundleService *services.BundleService
+	logger        *logger.Logger
+}
+
+func NewBundleHandler(bundleService *services.BundleService, log *logger.Logger) *BundleHandler {
+	return &BundleHandler{bundleService: bundleService, logger: log}
+}
+
+// GetBundleStatus tells the app whether the bundle embedded in its build
+// (?hash=) still matches what is published for the version.
+func (h *BundleHandler) GetBundleStatus(w http.ResponseWriter, r *http.Request) {
+	version := versionParam(r)
+	embedded := r.URL.Query().Get("hash")
+
+	current, err := h.bundleService.CurrentHash(version)
+	if err != nil {
+		h.logger.Errorw("Failed to compute bundle hash", "version", version, "error", err)
+		h.respondError(w, "Bundle not available", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":      true,
+		"version":      version,
+		"current_hash": current,
+		"stale":        embedded != current,
+	})
+}
+
+// DownloadBundle serves the same bundle `schemactl bundle` writes.
+func (h *BundleHandler) DownloadBundle(w http.ResponseWriter, r *http.Request) {
+	version := versionParam(r)
+
+	var buf bytes.Buffer
+	manifest, err := h.bundleService.Write(version, &buf)
+	if err != nil {
+		h.logger.Errorw("Failed to build bundle", "version", version, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="bundle_%s_%s.zip"`, version, manifest.BundleHash[:12]))
+	w.Header().Set("X-Bundle-Hash", manifest.BundleHash)
+	w.Write(buf.Bytes())
+}
+
+func (h *BundleHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"dynamic-ui-backend/internal/auth"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+type CanaryHandler struct {
+	canaryRepo    *repositories.CanaryRepository
+	canaryService *services.CanaryService
+	guard         *services.SubmissionGuard
+	logger        *logger.Logger
+}
+
+func NewCanaryHandler(canaryRepo *repositories.CanaryRepository, canaryService *services.CanaryService, guard *services.SubmissionGuard, log *logger.Logger) *CanaryHandler {
+	return &CanaryHandler{canaryRepo: canaryRepo, canaryService: canaryService, guard: guard, logger: log}
+}
+
+// GetCanaries lists the canaries of a version (?version=, default v1).
+func (h *CanaryHandler) GetCanaries(w http.ResponseWriter, r *http.Request) {
+	canaries, err := h.canaryRepo.GetAll(versionParam(r))
+	if err != nil {
+		h.respondError(w, "Failed to get canaries", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, canaries)
+}
+
+// StartCanary serves the screen's draft to a share of devices.
+// Body: {"screen": "home", "version": "v1", "percentage": 5, "error_threshold": 20}.
+func (h *CanaryHandler) StartCanary(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+
+	var req models.StartCanaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	canary, err := h.canaryService.Start(&req, claims.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to start canary", "screen", req.Screen, "version", req.Version, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondSuccess(w, canary)
+	h.logger.Infow("Canary started", "id", canary.ID, "screen", canary.Screen, "version", canary.Version, "percentage", canary.Percentage, "by", claims.Username)
+}
+
+// UpdateCanary adjusts a running canary. Body: {"percentage": 25}.
+func (h *CanaryHandler) UpdateCanary(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondError(w, "Invalid canary ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateCanaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	canary, err := h.canaryService.Update(id, &req, claims.UserID)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondSuccess(w, canary)
+	h.logger.Infow("Canary updated", "id", canary.ID, "screen", canary.Screen, "percentage", canary.Percentage, "error_threshold", canary.ErrorThreshold, "by", claims.Username)
+}
+
+// PromoteCanary publishes the canary document to everyone.
+func (h *CanaryHandler) PromoteCanary(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondError(w, "Invalid canary ID", http.StatusBadRequest)
+		return
+	}
+
+	canary, err := h.canaryService.Promote(id, claims.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to promote canary", "id", id, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondSuccess(w, canary)
+	h.logger.Infow("Canary promoted", "id", canary.ID, "screen", canary.Screen, "version", canary.Version, "by", claims.Username)
+}
+
+// AbortCanary stops a canary; everyone gets the published document again.
+func (h *CanaryHandler) AbortCanary(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondError(w, "Invalid canary ID", http.StatusBadRequest)
+		return
+	}
+
+	canary, err := h.canaryService.Abort(id, claims.UserID)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondSuccess(w, canary)
+	h.logger.Infow("Canary aborted", "id", canary.ID, "screen", canary.Screen, "version", canary.Version, "by", claims.Username)
+}
+
+// ReportRenderError is called by the app when it cannot render a schema
+// revision. Body: {"screen": "home", "version": "v1", "revision": "<hash>", "message": "..."}.
+// The device is identified by X-Device-ID (or ?device_id=); reports are
+// also counted per client IP. Reports go through the submission guard first.
+func (h *CanaryHandler) ReportRenderError(w http.ResponseWriter, r *http.Request) {
+	client := clientContextFromRequest(r)
+	if err := checkSubmissionClient(client); err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 16<<10))
+	if err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	var report models.RenderErrorReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if report.Screen == "" || report.Revision == "" {
+		h.respondError(w, "screen and revision are required", http.StatusBadRequest)
+		return
+	}
+
+	who := submitterFromRequest(r, client)
+	payloadHash, err := services.PayloadHash("render_error", &report)
+	if err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if !admitSubmission(w, r, h.guard, who, body, payloadHash) {
+		return
+	}
+
+	halted, err := h.canaryService.ReportError(&report, who)
+	if err != nil {
+		h.logger.Errorw("Failed to record render error", "screen", report.Screen, "revision", report.Revision, "error", err)
+		h.respondError(w, "Failed to record report", http.StatusInternalServerError)
+		return
+	}
+	if halted != nil {
+		h.logger.Infow("Canary halted", "id", halted.ID, "screen", halted.Screen, "version", halted.Version, "reason", halted.HaltReason)
+	}
+
+	h.guard.Accepted(who, payloadHash)
+	h.respondSuccess(w, map[string]string{"message": "Report received"})
+}
+
+func (h *CanaryHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *CanaryHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
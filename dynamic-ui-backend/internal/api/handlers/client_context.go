@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"dynamic-ui-backend/internal/models"
+	"net/http"
+	"strings"
+)
+
+// clientContextFromRequest reads the app identification headers, falling
+// back to query parameters for clients that cannot set headers.
+func clientContextFromRequest(r *http.Request) models.ClientContext {
+	get := func(header, param string) string {
+		if v := r.Header.Get(header); v != "" {
+			return strings.TrimSpace(v)
+		}
+		return strings.TrimSpace(r.URL.Query().Get(param))
+	}
+
+	return models.ClientContext{
+		Platform:   strings.ToLower(get("X-Platform", "platform")),
+		FormFactor: strings.ToLower(get("X-Form-Factor", "form_factor")),
+		AppVersion: get("X-App-Version", "app_version"),
+		DeviceID:   get("X-Device-ID", "device_id"),
+	}
+}
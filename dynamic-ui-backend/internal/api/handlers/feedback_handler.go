@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+)
+
+type FeedbackHandler struct {
+	screens         *screenResolver
+	feedbackService *services.FeedbackService
+	guard           *services.SubmissionGuard
+	logger          *logger.Logger
+}
+
+func NewFeedbackHandler(uiService *services.UIService, flagService *services.FlagService, canaryService *services.CanaryService, killSwitchService *services.KillSwitchService, feedbackService *services.FeedbackService, guard *services.SubmissionGuard, log *logger.Logger) *FeedbackHandler {
+	return &FeedbackHandler{
+		screens: &screenResolver{
+			uiService:         uiService,
+			flagService:       flagService,
+			canaryService:     canaryService,
+			killSwitchService: killSwitchService,
+			logger:            log,
+		},
+		feedbackService: feedbackService,
+		guard:           guard,
+		logger:          log,
+	}
+}
+
+// SubmitFeedback stores the state collected by a screen's submit_feedback
+// action. Body: {"screen": "survey", "version": "v1", "state": {"support_rating": 4}}.
+// The values are checked against the screen document this device is served,
+// after feature flags and kill switches, so hidden fields are not required;
+// rejected submissions get 422 with one error per field.
+// The device is identified by X-Device-ID (or ?device_id=); a bearer token
+// is optional and links the response to the user. Submissions go through
+// the submission guard first.
+func (h *FeedbackHandler) SubmitFeedback(w http.ResponseWriter, r *http.Request) {
+	client := clientContextFromRequest(r)
+	if err := checkSubmissionClient(client); err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 64<<10))
+	if err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	var req models.SubmitFeedbackRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if req.Version == "" {
+		req.Version = "v1"
+	}
+	if req.Screen == "" || len(req.State) == 0 {
+		h.respondError(w, "screen and state are required", http.StatusBadRequest)
+		return
+	}
+
+	who := submitterFromRequest(r, client)
+	payloadHash, err := services.PayloadHash("feedback", &req)
+	if err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if !admitSubmission(w, r, h.guard, who, body, payloadHash) {
+		return
+	}
+
+	schema, revision, err := h.screens.resolve(req.Screen, req.Version, client)
+	if err != nil {
+		h.respondError(w, "Screen not found", http.StatusNotFound)
+		return
+	}
+
+	claims, allowed := authorizeSubmission(w, r, schema)
+	if !allowed {
+		return
+	}
+	schema = h.screens.personalize(schema, req.Screen, client)
+
+	var userID *int
+	if claims != nil {
+		userID = &claims.UserID
+	}
+	response, fieldErrors, err := h.feedbackService.Submit(&req, schema, revision, client, userID)
+	if err != nil {
+		h.logger.Errorw("Failed to store feedback", "screen", req.Screen, "version", req.Version, "error", err)
+		h.respondError(w, "Failed to store feedback", http.StatusInternalServerError)
+		return
+	}
+	if len(fieldErrors) > 0 {
+		respondFieldErrors(w, fieldErrors)
+		return
+	}
+
+	h.guard.Accepted(who, payloadHash)
+	h.respondSuccess(w, map[string]interface{}{"id": response.ID})
+	h.logger.Infow("Feedback received", "id", response.ID, "screen", response.Screen, "version", response.Version, "platform", response.Platform)
+}
+
+// GetFeedbackSummary aggregates a screen's feedback responses:
+// ?screen=survey&version=v1&key=support_rating&interval=day, plus the
+// filters read by feedbackFilterFromRequest.
+func (h *FeedbackHandler) GetFeedbackSummary(w http.ResponseWriter, r *http.Request) {
+	filter, err := feedbackFilterFromRequest(r)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	interval := r.URL.Query().Get("interval")
+	if interval == "" {
+		interval = "day"
+	}
+
+	summary, err := h.feedbackService.Summary(filter, interval, r.URL.Query().Get("key"))
+	if err != nil {
+		h.logger.Errorw("Failed to summarize feedback", "screen", filter.Screen, "version", filter.Version, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.respondSuccess(w, summary)
+}
+
+// ExportFeedback streams a screen's feedback responses as a download:
+// ?screen=survey&version=v1&format=csv (or jsonl), plus the filters read by
+// feedbackFilterFromRequest.
+func (h *FeedbackHandler) ExportFeedback(w http.ResponseWriter, r *http.Request) {
+	filter, err := feedbackFilterFromRequest(r)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = services.FeedbackExportCSV
+	}
+
+	export, err := h.feedbackService.Export(filter, format)
+	if err != nil {
+		h.logger.Errorw("Failed to prepare feedback export", "screen", filter.Screen, "version", filter.Version, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, export.Filename()))
+	w.Header().Set("Cache-Control", "no-store")
+	rc := http.NewResponseController(w)
+	// Large exports can outlive the server's WriteTimeout.
+	rc.SetWriteDeadline(time.Time{})
+	flush := func() { rc.Flush() }
+
+	// The status is already sent; a failure can only cut the download short.
+	if err := export.Stream(w, flush); err != nil {
+		h.logger.Errorw("Feedback export interrupted", "screen", filter.Screen, "version", filter.Version, "error", err)
+	}
+}
+
+// feedbackFilterFromRequest reads ?screen= (required), ?version=, ?from=,
+// ?to=, ?app_version= and ?platform=. Dates are YYYY-MM-DD (to is
+// inclusive) or RFC 3339 timestamps.
+func feedbackFilterFromRequest(r *http.Request) (*models.FeedbackFilter, error) {
+	query := r.URL.Query()
+	filter := &models.FeedbackFilter{
+		Screen:     query.Get("screen"),
+		Version:    versionParam(r),
+		AppVersion: query.Get("app_version"),
+		Platform:   strings.ToLower(query.Get("platform")),
+	}
+	if filter.Screen == "" {
+		return nil, fmt.Errorf("screen parameter is required")
+	}
+
+	var err error
+	if filter.From, err = parseDateParam(query.Get("from"), false); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseDateParam(query.Get("to"), true); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func (h *FeedbackHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *FeedbackHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
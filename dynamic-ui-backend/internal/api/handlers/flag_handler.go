@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"dynamic-ui-backend/internal/auth"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+type FlagHandler struct {
+	flagRepo    *repositories.FlagRepository
+	flagService *services.FlagService
+	logger      *logger.Logger
+}
+
+func NewFlagHandler(flagRepo *repositories.FlagRepository, flagService *services.FlagService, log *logger.Logger) *FlagHandler {
+	return &FlagHandler{flagRepo: flagRepo, flagService: flagService, logger: log}
+}
+
+// GetClientFlags returns the evaluated flag set for the calling app install.
+func (h *FlagHandler) GetClientFlags(w http.ResponseWriter, r *http.Request) {
+	client := clientContextFromRequest(r)
+
+	values, err := h.flagService.Evaluate(client)
+	if err != nil {
+		h.logger.Errorw("Failed to evaluate flags", "error", err)
+		h.respondError(w, "Failed to evaluate flags", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":      true,
+		"flags":        values,
+		"evaluated_at": time.Now(),
+	})
+}
+
+func (h *FlagHandler) GetAllFlags(w http.ResponseWriter, r *http.Request) {
+	flags, err := h.flagRepo.GetAll()
+	if err != nil {
+		h.respondError(w, "Failed to get flags", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, flags)
+}
+
+func (h *FlagHandler) GetFlag(w http.ResponseWriter, r *http.Request) {
+	flag, err := h.flagRepo.GetByKey(mux.Vars(r)["key"])
+	if err != nil {
+		h.respondError(w, "Flag not found", http.StatusNotFound)
+		return
+	}
+	h.respondSuccess(w, flag)
+}
+
+func (h *FlagHandler) CreateFlag(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+
+	var req models.CreateFlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if req.Rules == nil {
+		req.Rules = []models.FlagRule{}
+	}
+
+	if err := services.ValidateFlag(req.Key, req.ValueType, req.DefaultValue, req.Rules); err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flag, err := h.flagRepo.Create(&req, claims.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to create flag", "key", req.Key, "error", err)
+		h.respondError(w, "Failed to create flag", http.StatusInternalServerError)
+		return
+	}
+	h.flagService.Invalidate()
+
+	h.respondSuccess(w, flag)
+	h.logger.Infow("Flag created", "key", flag.Key, "by", claims.Username)
+}
+
+func (h *FlagHandler) UpdateFlag(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	key := mux.Vars(r)["key"]
+
+	existing, err := h.flagRepo.GetByKey(key)
+	if err != nil {
+		h.respondError(w, "Flag not found", http.StatusNotFound)
+		return
+	}
+
+	var req models.UpdateFlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	defaultValue := existing.DefaultValue
+	if req.DefaultValue != nil {
+		defaultValue = req.DefaultValue
+	}
+	rules := existing.Rules
+	if req.Rules != nil {
+		if *req.Rules == nil {
+			*req.Rules = []models.FlagRule{}
+		}
+		rules = *req.Rules
+	}
+	if err := services.ValidateFlag(key, existing.ValueType, defaultValue, rules); err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flag, err := h.flagRepo.Update(key, &req, claims.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to update flag", "key", key, "error", err)
+		h.respondError(w, "Failed to update flag", http.StatusInternalServerError)
+		return
+	}
+	h.flagService.Invalidate()
+
+	h.respondSuccess(w, flag)
+	h.logger.Infow("Flag updated", "key", key, "by", claims.Username)
+}
+
+func (h *FlagHandler) DeleteFlag(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	key := mux.Vars(r)["key"]
+
+	if err := h.flagRepo.Delete(key); err != nil {
+		h.respondError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	h.flagService.Invalidate()
+
+	h.respondSuccess(w, map[string]string{"message": "Flag deleted"})
+	h.logger.Infow("Flag deleted", "key", key, "by", claims.Username)
+}
+
+func (h *FlagHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *FlagHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
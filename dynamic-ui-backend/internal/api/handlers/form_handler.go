@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+type FormHandler struct {
+	formRepo    *repositories.FormRepository
+	formService *services.FormService
+	screens     *screenResolver
+	guard       *services.SubmissionGuard
+	logger      *logger.Logger
+}
+
+func NewFormHandler(formRepo *repositories.FormRepository, formService *services.FormService, uiService *services.UIService, flagService *services.FlagService, canaryService *services.CanaryService, killSwitchService *services.KillSwitchService, guard *services.SubmissionGuard, log *logger.Logger) *FormHandler {
+	return &FormHandler{
+		formRepo:    formRepo,
+		formService: formService,
+		screens: &screenResolver{
+			uiService:         uiService,
+			flagService:       flagService,
+			canaryService:     canaryService,
+			killSwitchService: killSwitchService,
+			logger:            log,
+		},
+		guard:  guard,
+		logger: log,
+	}
+}
+
+// SubmitForm is the single endpoint every submit_form action posts to.
+// Body: {"screen": "seller_application", "version": "v1", "form_id": "seller", "values": {...}}.
+// The values are checked against the form in the screen document this
+// device is served, after feature flags and kill switches, so hidden fields
+// are not required; rejected submissions get 422 with one error per field.
+// The device is identified by X-Device-ID (or ?device_id=); a bearer token
+// is optional and links the submission to the user. Submissions go through
+// the submission guard first.
+func (h *FormHandler) SubmitForm(w http.ResponseWriter, r *http.Request) {
+	client := clientContextFromRequest(r)
+	if err := checkSubmissionClient(client); err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 64<<10))
+	if err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	var req models.SubmitFormRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if req.Version == "" {
+		req.Version = "v1"
+	}
+	if req.Screen == "" || req.FormID == "" {
+		h.respondError(w, "screen and form_id are required", http.StatusBadRequest)
+		return
+	}
+
+	who := submitterFromRequest(r, client)
+	payloadHash, err := services.PayloadHash("form", &req)
+	if err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if !admitSubmission(w, r, h.guard, who, body, payloadHash) {
+		return
+	}
+
+	schema, revision, err := h.screens.resolve(req.Screen, req.Version, client)
+	if err != nil {
+		h.respondError(w, "Screen not found", http.StatusNotFound)
+		return
+	}
+	claims, allowed := authorizeSubmission(w, r, schema)
+	if !allowed {
+		return
+	}
+	schema = h.screens.personalize(schema, req.Screen, client)
+
+	form, err := services.FormOf(schema, req.FormID)
+	if err != nil {
+		h.logger.Errorw("Invalid form definition", "screen", req.Screen, "form", req.FormID, "error", err)
+		h.respondError(w, "Form is misconfigured", http.StatusInternalServerError)
+		return
+	}
+	if form == nil {
+		h.respondError(w, "Form not found", http.StatusNotFound)
+		return
+	}
+
+	var userID *int
+	if claims != nil {
+		userID = &claims.UserID
+	}
+	submission, fieldErrors, err := h.formService.Submit(&req, form, schema, revision, client, userID)
+	if err != nil {
+		h.logger.Errorw("Failed to store form submission", "screen", req.Screen, "form", req.FormID, "error", err)
+		h.respondError(w, "Failed to store submission", http.StatusInternalServerError)
+		return
+	}
+	if len(fieldErrors) > 0 {
+		respondFieldErrors(w, fieldErrors)
+		return
+	}
+
+	h.guard.Accepted(who, payloadHash)
+	h.respondSuccess(w, map[string]interface{}{"id": submission.ID})
+	h.logger.Infow("Form submitted", "id", submission.ID, "form", submission.FormID, "screen", submission.Screen, "platform", submission.Platform)
+}
+
+// GetSubmissions lists the latest submissions of a form (?limit=, default
+// 50, at most 500).
+func (h *FormHandler) GetSubmissions(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 500 {
+			h.respondError(w, "limit must be between 1 and 500", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	submissions, err := h.formRepo.GetByForm(mux.Vars(r)["form_id"], limit)
+	if err != nil {
+		h.respondError(w, "Failed to get submissions", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, submissions)
+}
+
+func (h *FormHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *FormHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
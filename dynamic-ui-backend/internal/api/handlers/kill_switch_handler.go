@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"dynamic-ui-backend/internal/auth"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+type KillSwitchHandler struct {
+	killSwitchRepo    *repositories.KillSwitchRepository
+	killSwitchService *services.KillSwitchService
+	logger            *logger.Logger
+}
+
+func NewKillSwitchHandler(killSwitchRepo *repositories.KillSwitchRepository, killSwitchService *services.KillSwitchService, log *logger.Logger) *KillSwitchHandler {
+	return &KillSwitchHandler{killSwitchRepo: killSwitchRepo, killSwitchService: killSwitchService, logger: log}
+}
+
+func (h *KillSwitchHandler) GetAllKillSwitches(w http.ResponseWriter, r *http.Request) {
+	switches, err := h.killSwitchRepo.GetAll()
+	if err != nil {
+		h.respondError(w, "Failed to get kill switches", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, switches)
+}
+
+// CreateKillSwitch takes effect on the next schema request.
+// Body: {"widget_type": "draggable_ai_fab", "platform": "android", "max_app_version": "2.3.1", "reason": "..."}.
+func (h *KillSwitchHandler) CreateKillSwitch(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+
+	var req models.CreateKillSwitchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if err := services.ValidateKillSwitch(req.WidgetType, req.WidgetID, req.MinAppVersion, req.MaxAppVersion); err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ks, err := h.killSwitchRepo.Create(&req, claims.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to create kill switch", "widget_type", req.WidgetType, "widget_id", req.WidgetID, "error", err)
+		h.respondError(w, "Failed to create kill switch", http.StatusInternalServerError)
+		return
+	}
+	h.killSwitchService.Invalidate()
+
+	h.respondSuccess(w, ks)
+	h.logger.Infow("Kill switch created", "id", ks.ID, "widget_type", ks.WidgetType, "widget_id", ks.WidgetID, "by", claims.Username)
+}
+
+func (h *KillSwitchHandler) UpdateKillSwitch(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondError(w, "Invalid kill switch ID", http.StatusBadRequest)
+		return
+	}
+
+	existing, err := h.killSwitchRepo.GetByID(id)
+	if err != nil {
+		h.respondError(w, "Kill switch not found", http.StatusNotFound)
+		return
+	}
+
+	var req models.UpdateKillSwitchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	minVersion, maxVersion := existing.MinAppVersion, existing.MaxAppVersion
+	if req.MinAppVersion != nil {
+		minVersion = *req.MinAppVersion
+	}
+	if req.MaxAppVersion != nil {
+		maxVersion = *req.MaxAppVersion
+	}
+	if err := services.ValidateKillSwitch(existing.WidgetType, existing.WidgetID, minVersion, maxVersion); err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ks, err := h.killSwitchRepo.Update(id, &req, claims.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to update kill switch", "id", id, "error", err)
+		h.respondError(w, "Failed to update kill switch", http.StatusInternalServerError)
+		return
+	}
+	h.killSwitchService.Invalidate()
+
+	h.respondSuccess(w, ks)
+	h.logger.Infow("Kill switch updated", "id", id, "active", ks.IsActive, "by", claims.Username)
+}
+
+func (h *KillSwitchHandler) DeleteKillSwitch(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondError(w, "Invalid kill switch ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.killSwitchRepo.Delete(id); err != nil {
+		h.respondError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	h.killSwitchService.Invalidate()
+
+	h.respondSuccess(w, map[string]string{"message": "Kill switch deleted"})
+	h.logger.Infow("Kill switch deleted", "id", id, "by", claims.Username)
+}
+
+func (h *KillSwitchHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *KillSwitchHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
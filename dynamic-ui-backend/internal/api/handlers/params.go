@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+)
+
+// parseDateParam reads a date or timestamp query parameter. A bare date
+// used as an upper bound means the end of that day.
+func parseDateParam(value string, end bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date '%s'", value)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
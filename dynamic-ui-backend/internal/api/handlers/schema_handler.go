@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"dynamic-ui-backend/internal/auth"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/internal/validation"
+	"dynamic-ui-backend/pkg/logger"
+)
+
+// SchemaHandler serves the admin tooling around schema sets: archives and drafts.
+type SchemaHandler struct {
+	uiService         *services.UIService
+	archiveService    *services.ArchiveService
+	validationService *services.ValidationService
+	logger            *logger.Logger
+}
+
+func NewSchemaHandler(
+	uiService *services.UIService,
+	archiveService *services.ArchiveService,
+	validationService *services.ValidationService,
+	log *logger.Logger,
+) *SchemaHandler {
+	return &SchemaHandler{
+		uiService:         uiService,
+		archiveService:    archiveService,
+		validationService: validationService,
+		logger:            log,
+	}
+}
+
+func (h *SchemaHandler) Export(w http.ResponseWriter, r *http.Request) {
+	version := versionParam(r)
+
+	var buf bytes.Buffer
+	manifest, err := h.archiveService.Export(version, &buf)
+	if err != nil {
+		h.logger.Errorw("Failed to export schemas", "version", version, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="schemas_%s_%s.zip"`, version, manifest.ExportedAt.Format("20060102_150405")))
+	w.Write(buf.Bytes())
+
+	h.logger.Infow("Schemas exported", "version", version, "files", len(manifest.Files))
+}
+
+func (h *SchemaHandler) Import(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	version := versionParam(r)
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 50<<20))
+	if err != nil {
+		h.respondError(w, "Archive too large or unreadable", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.archiveService.Import(version, data, dryRun)
+	if err != nil {
+		h.logger.Errorw("Failed to import schemas", "version", version, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondSuccess(w, report)
+	h.logger.Infow("Schemas imported", "version", version, "dry_run", dryRun,
+		"added", len(report.Added), "modified", len(report.Modified), "by", claims.Username)
+}
+
+func (h *SchemaHandler) ListDrafts(w http.ResponseWriter, r *http.Request) {
+	version := versionParam(r)
+
+	files, err := h.uiService.DraftFiles(version)
+	if err != nil {
+		h.respondError(w, "Failed to list drafts", http.StatusInternalServerError)
+		return
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	h.respondSuccess(w, map[string]interface{}{"version": version, "drafts": paths})
+}
+
+func (h *SchemaHandler) PublishDraft(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	version := versionParam(r)
+	path := r.URL.Query().Get("path")
+
+	if _, _, err := services.ParseSchemaPath(path); err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.uiService.PublishDraft(version, path); err != nil {
+		h.logger.Errorw("Failed to publish draft", "version", version, "path", path, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondSuccess(w, map[string]string{"message": "Draft published", "path": path, "version": version})
+	h.logger.Infow("Draft published", "version", version, "path", path, "by", claims.Username)
+}
+
+func (h *SchemaHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	version := versionParam(r)
+	path := r.URL.Query().Get("path")
+
+	revisions, err := h.uiService.ListRevisions(version, path)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.respondSuccess(w, map[string]interface{}{"version": version, "path": path, "revisions": revisions})
+}
+
+func (h *SchemaHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	version := versionParam(r)
+	path := r.URL.Query().Get("path")
+
+	revision, err := h.uiService.Rollback(version, path, r.URL.Query().Get("revision"))
+	if err != nil {
+		h.logger.Errorw("Failed to roll back", "version", version, "path", path, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondSuccess(w, map[string]interface{}{"message": "Rolled back", "path": path, "version": version, "revision": revision})
+	h.logger.Infow("Schema rolled back", "version", version, "path", path, "revision", revision.ID, "by", claims.Username)
+}
+
+// ValidateScreens validates the published screens of a version (?screen=
+// limits it to one).
+func (h *SchemaHandler) ValidateScreens(w http.ResponseWriter, r *http.Request) {
+	version := versionParam(r)
+
+	results, err := h.validationService.ValidateVersion(version, r.URL.Query().Get("screen"))
+	if err != nil {
+		h.logger.Errorw("Failed to validate schemas", "version", version, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	valid := true
+	for _, issues := range results {
+		if validation.HasErrors(issues) {
+			valid = false
+		}
+	}
+	h.respondSuccess(w, map[string]interface{}{"version": version, "valid": valid, "screens": results})
+}
+
+// ValidateDocument validates a screen document posted in the request body.
+func (h *SchemaHandler) ValidateDocument(w http.ResponseWriter, r *http.Request) {
+	var doc map[string]interface{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 5<<20)).Decode(&doc); err != nil {
+		h.respondError(w, "Invalid schema JSON", http.StatusBadRequest)
+		return
+	}
+
+	issues, err := h.validationService.Validate(doc)
+	if err != nil {
+		h.logger.Errorw("Failed to validate schema", "error", err)
+		h.respondError(w, "Failed to validate schema", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, map[string]interface{}{"valid": !validation.HasErrors(issues), "issues": issues})
+}
+
+func (h *SchemaHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *SchemaHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
+
+func versionParam(r *http.Request) string {
+	version := r.URL.Query().Get("version")
+	if version == "" {
+		version = "v1"
+	}
+	return version
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+)
+
+// screenResolver builds the document a client is served. GetScreen and the
+// submission endpoints share it, so submissions are checked against exactly
+// what the device was shown.
+type screenResolver struct {
+	uiService         *services.UIService
+	flagService       *services.FlagService
+	canaryService     *services.CanaryService
+	killSwitchService *services.KillSwitchService
+	logger            *logger.Logger
+}
+
+// resolve returns the screen with the client's platform overrides, and the
+// canary revision when the device is inside a running canary. The document
+// is shared between requests and must not be modified.
+func (s *screenResolver) resolve(screenName, version string, client models.ClientContext) (map[string]interface{}, string, error) {
+	schema, err := s.uiService.GetScreenSchemaFor(screenName, version, client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Devices inside a running canary get the candidate document instead,
+	// with the same platform overrides.
+	revision := ""
+	if canary, doc, ok := s.canaryService.ForClient(version, screenName, client.DeviceID); ok {
+		if merged, _, err := s.uiService.ApplyOverrides(doc, version, screenName, client); err != nil {
+			s.logger.Errorw("Failed to apply overrides to canary", "screen", screenName, "canary", canary.ID, "error", err)
+		} else {
+			schema = merged
+			revision = canary.Revision
+		}
+	}
+	return schema, revision, nil
+}
+
+// personalize returns a copy of a resolved screen with the client's feature
+// flags and kill switches applied.
+func (s *screenResolver) personalize(schema map[string]interface{}, screenName string, client models.ClientContext) map[string]interface{} {
+	// Cached schemas are shared between requests; work on a copy.
+	schema = services.CloneDocument(schema).(map[string]interface{})
+
+	flags, err := s.flagService.Evaluate(client)
+	if err != nil {
+		s.logger.Errorw("Failed to evaluate flags for schema", "screen", screenName, "error", err)
+		flags = map[string]interface{}{}
+	}
+	schema = services.ApplyFlags(schema, flags).(map[string]interface{})
+
+	switches, err := s.killSwitchService.ForClient(client)
+	if err != nil {
+		s.logger.Errorw("Failed to load kill switches for schema, using the last known set", "screen", screenName, "error", err)
+	}
+	services.ApplyKillSwitches(schema, switches)
+	return schema
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"dynamic-ui-backend/internal/auth"
+	"dynamic-ui-backend/internal/forms"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/services"
+)
+
+// Lengths of the client columns submissions are stored in (migrations 006
+// and 007); device IDs also go into schema_canary_errors (004) and
+// blocked_submitters (008).
+const (
+	maxPlatformLength   = 20
+	maxAppVersionLength = 20
+	maxDeviceIDLength   = 100
+)
+
+// checkSubmissionClient rejects client headers that are missing or too long
+// to be stored with a submission.
+func checkSubmissionClient(client models.ClientContext) error {
+	switch {
+	case client.DeviceID == "":
+		return fmt.Errorf("Device ID is required")
+	case len(client.DeviceID) > maxDeviceIDLength:
+		return fmt.Errorf("Device ID must be at most %d characters", maxDeviceIDLength)
+	case len(client.Platform) > maxPlatformLength:
+		return fmt.Errorf("Platform must be at most %d characters", maxPlatformLength)
+	case len(client.AppVersion) > maxAppVersionLength:
+		return fmt.Errorf("App version must be at most %d characters", maxAppVersionLength)
+	}
+	return nil
+}
+
+// authorizeSubmission checks who submits data from a screen. A bearer token
+// is optional and identifies the user, but an invalid one is rejected, and
+// screens that are not public need a token with an allowed role. On failure
+// it writes the error response and returns false.
+func authorizeSubmission(w http.ResponseWriter, r *http.Request, schema map[string]interface{}) (*auth.Claims, bool) {
+	fail := func(status int, message, code string) (*auth.Claims, bool) {
+		if status == http.StatusUnauthorized {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Success: false, Error: message, Code: code})
+		return nil, false
+	}
+
+	var claims *auth.Claims
+	if header := r.Header.Get("Authorization"); header != "" {
+		token, ok := auth.BearerToken(header)
+		if !ok {
+			return fail(http.StatusUnauthorized, "Invalid authorization header", "UNAUTHORIZED")
+		}
+		var err error
+		if claims, err = auth.ValidateToken(token); err != nil {
+			return fail(http.StatusUnauthorized, "Invalid token", "UNAUTHORIZED")
+		}
+	}
+
+	policy, err := services.ScreenAccess(schema)
+	if err != nil {
+		return fail(http.StatusForbidden, "Access denied", "FORBIDDEN")
+	}
+	if policy.IsPublic() {
+		return claims, true
+	}
+	if claims == nil {
+		return fail(http.StatusUnauthorized, "Authentication required", "UNAUTHORIZED")
+	}
+	if !policy.AllowsRole(claims.Role) {
+		return fail(http.StatusForbidden, "Access denied", "FORBIDDEN")
+	}
+	return claims, true
+}
+
+// submitterFromRequest identifies who is behind a submission. The client IP
+// is taken from X-Forwarded-For only when TRUST_PROXY_HEADERS is "true",
+// i.e. the server runs behind a proxy that sets it. Every proxy appends the
+// address it was reached from, so only the entries added by our own proxies
+// can be trusted: the client is the TRUSTED_PROXY_HOPS-th entry from the
+// right (default 1). Anything further left was sent by the client.
+func submitterFromRequest(r *http.Request, client models.ClientContext) services.Submitter {
+	ip := ""
+	if os.Getenv("TRUST_PROXY_HEADERS") == "true" {
+		ip = forwardedClientIP(r.Header.Values("X-Forwarded-For"), trustedProxyHops())
+	}
+	if ip == "" {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ip = host
+	}
+	return services.Submitter{DeviceID: client.DeviceID, IP: ip}
+}
+
+func trustedProxyHops() int {
+	hops, err := strconv.Atoi(os.Getenv("TRUSTED_PROXY_HOPS"))
+	if err != nil || hops < 1 {
+		return 1
+	}
+	return hops
+}
+
+// forwardedClientIP returns the normalized address hops entries from the
+// right of the X-Forwarded-For headers, or "" when there are fewer entries
+// or it is not an IP.
+func forwardedClientIP(headers []string, hops int) string {
+	var entries []string
+	for _, h := range headers {
+		entries = append(entries, strings.Split(h, ",")...)
+	}
+	if len(entries) < hops {
+		return ""
+	}
+	parsed := net.ParseIP(strings.TrimSpace(entries[len(entries)-hops]))
+	if parsed == nil {
+		return ""
+	}
+	return parsed.String()
+}
+
+// admitSubmission runs the submission guard. On rejection it writes the
+// error response and returns false.
+func admitSubmission(w http.ResponseWriter, r *http.Request, guard *services.SubmissionGuard, who services.Submitter, body []byte, payloadHash string) bool {
+	rejection := guard.Admit(who, body, payloadHash, services.SubmissionProof{
+		Work:        r.Header.Get("X-Proof-Of-Work"),
+		Attestation: r.Header.Get("X-App-Attestation"),
+	})
+	if rejection == nil {
+		return true
+	}
+	if rejection.RetryAfter > 0 {
+		w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(rejection.RetryAfter.Seconds()))))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(rejection.Status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{Success: false, Error: rejection.Message, Code: rejection.Code})
+	return false
+}
+
+// respondFieldErrors writes the response for a submission whose values were
+// rejected, the same for every submission endpoint so the app renders field
+// errors one way.
+func respondFieldErrors(w http.ResponseWriter, fieldErrors []forms.FieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   "Validation failed",
+		"code":    "VALIDATION_FAILED",
+		"data":    fieldErrors,
+	})
+}
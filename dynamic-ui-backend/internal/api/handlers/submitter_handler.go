@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"dynamic-ui-backend/internal/auth"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+// maxBlockHours caps manual blocks at ten years; use hours 0 for a
+// permanent block.
+const maxBlockHours = 10 * 365 * 24
+
+type SubmitterHandler struct {
+	blockedRepo *repositories.BlockedSubmitterRepository
+	guard       *services.SubmissionGuard
+	logger      *logger.Logger
+}
+
+func NewSubmitterHandler(blockedRepo *repositories.BlockedSubmitterRepository, guard *services.SubmissionGuard, log *logger.Logger) *SubmitterHandler {
+	return &SubmitterHandler{blockedRepo: blockedRepo, guard: guard, logger: log}
+}
+
+// GetBlockedSubmitters lists blocked devices and IPs. Expired automatic
+// blocks are included with ?expired=true.
+func (h *SubmitterHandler) GetBlockedSubmitters(w http.ResponseWriter, r *http.Request) {
+	blocked, err := h.blockedRepo.GetAll(r.URL.Query().Get("expired") == "true")
+	if err != nil {
+		h.respondError(w, "Failed to get blocked submitters", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, blocked)
+}
+
+// BlockSubmitter blocks a device or IP. Body: {"kind": "ip", "value": "203.0.113.7", "reason": "...", "hours": 72}.
+// IPs are stored in their normalized form so they match what the guard sees.
+func (h *SubmitterHandler) BlockSubmitter(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+
+	var req models.BlockSubmitterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if req.Kind != models.SubmitterDevice && req.Kind != models.SubmitterIP {
+		h.respondError(w, "kind must be 'device' or 'ip'", http.StatusBadRequest)
+		return
+	}
+	req.Value = strings.TrimSpace(req.Value)
+	if req.Value == "" || len(req.Value) > maxDeviceIDLength {
+		h.respondError(w, fmt.Sprintf("value is required and must be at most %d characters", maxDeviceIDLength), http.StatusBadRequest)
+		return
+	}
+	if req.Hours < 0 || req.Hours > maxBlockHours {
+		h.respondError(w, fmt.Sprintf("hours must be between 0 and %d", maxBlockHours), http.StatusBadRequest)
+		return
+	}
+	if req.Kind == models.SubmitterIP {
+		ip := net.ParseIP(req.Value)
+		if ip == nil {
+			h.respondError(w, "value is not a valid IP address", http.StatusBadRequest)
+			return
+		}
+		req.Value = ip.String()
+	}
+
+	var expiresAt *time.Time
+	if req.Hours > 0 {
+		t := time.Now().Add(time.Duration(req.Hours) * time.Hour)
+		expiresAt = &t
+	}
+	blocked, err := h.blockedRepo.Block(req.Kind, req.Value, req.Reason, false, expiresAt, &claims.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to block submitter", "kind", req.Kind, "value", req.Value, "error", err)
+		h.respondError(w, "Failed to block submitter", http.StatusInternalServerError)
+		return
+	}
+	h.guard.InvalidateBlocked()
+
+	h.respondSuccess(w, blocked)
+	h.logger.Infow("Submitter blocked", "kind", blocked.Kind, "value", blocked.Value, "by", claims.Username)
+}
+
+// UnblockSubmitter removes a block.
+func (h *SubmitterHandler) UnblockSubmitter(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.blockedRepo.Delete(id); err != nil {
+		h.respondError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	h.guard.InvalidateBlocked()
+
+	h.respondSuccess(w, map[string]string{"message": "Submitter unblocked"})
+	h.logger.Infow("Submitter unblocked", "id", id, "by", claims.Username)
+}
+
+func (h *SubmitterHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *SubmitterHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
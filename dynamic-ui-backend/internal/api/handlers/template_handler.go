@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"dynamic-ui-backend/internal/auth"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+)
+
+type TemplateHandler struct {
+	templateService *services.TemplateService
+	logger          *logger.Logger
+}
+
+func NewTemplateHandler(templateService *services.TemplateService, log *logger.Logger) *TemplateHandler {
+	return &TemplateHandler{templateService: templateService, logger: log}
+}
+
+// ListTemplates describes the templates of a version and their parameters.
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templateService.List(versionParam(r))
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.respondSuccess(w, templates)
+}
+
+// InstantiateTemplate creates a draft screen from a template.
+// Body: {"template": "campaign", "screen": "ramadan_sale", "params": {"title": "..."}}.
+func (h *TemplateHandler) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+
+	var req models.InstantiateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.templateService.Instantiate(&req)
+	if err != nil {
+		h.logger.Errorw("Failed to instantiate template", "template", req.Template, "screen", req.Screen, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondCreated(w, result)
+	h.logger.Infow("Screen created from template", "template", req.Template, "screen", req.Screen, "version", result.Version, "created", result.Created, "by", claims.Username)
+}
+
+// CloneScreen copies a published screen into a new draft screen.
+// Body: {"source": "home", "screen": "home_ramadan", "version": "v2"}.
+func (h *TemplateHandler) CloneScreen(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+
+	var req models.CloneScreenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.templateService.Clone(&req)
+	if err != nil {
+		h.logger.Errorw("Failed to clone screen", "source", req.Source, "screen", req.Screen, "error", err)
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respondCreated(w, result)
+	h.logger.Infow("Screen cloned", "source", req.Source, "screen", req.Screen, "version", result.Version, "created", result.Created, "by", claims.Username)
+}
+
+// respondCreated reports a generated screen; one that failed validation is
+// returned with its issues and a 422 status.
+func (h *TemplateHandler) respondCreated(w http.ResponseWriter, result *services.CreatedScreen) {
+	if result.Created {
+		h.respondSuccess(w, result)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   "Generated screen has validation errors",
+		"data":    result,
+	})
+}
+
+func (h *TemplateHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *TemplateHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"dynamic-ui-backend/internal/auth"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+type TrashHandler struct {
+	trashService *services.TrashService
+	logger       *logger.Logger
+}
+
+func NewTrashHandler(trashService *services.TrashService, log *logger.Logger) *TrashHandler {
+	return &TrashHandler{trashService: trashService, logger: log}
+}
+
+// GetTrash lists the deleted categories and brands.
+func (h *TrashHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	trash, err := h.trashService.List()
+	if err != nil {
+		h.respondError(w, "Failed to get trash", http.StatusInternalServerError)
+		return
+	}
+	h.respondSuccess(w, trash)
+}
+
+// RestoreItem takes a category or brand out of the trash.
+func (h *TrashHandler) RestoreItem(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	kind, id, ok := h.trashItem(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := h.trashService.Restore(kind, id, claims.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			h.respondError(w, "Not found in trash", http.StatusNotFound)
+		case errors.Is(err, repositories.ErrParentDeleted):
+			h.respondError(w, "Parent category is in the trash; restore it first", http.StatusConflict)
+		default:
+			h.logger.Errorw("Failed to restore", "kind", kind, "id", id, "error", err)
+			h.respondError(w, "Failed to restore", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.respondSuccess(w, item)
+	h.logger.Infow("Restored from trash", "kind", kind, "id", id, "by", claims.Username)
+}
+
+// PurgeItem permanently deletes a category or brand in the trash.
+func (h *TrashHandler) PurgeItem(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+	kind, id, ok := h.trashItem(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.trashService.Purge(kind, id); err != nil {
+		switch {
+		case errors.Is(err, services.ErrImageCleanup):
+			h.logger.Errorw("Purged, but image cleanup failed", "kind", kind, "id", id, "error", err)
+		case errors.Is(err, sql.ErrNoRows):
+			h.respondError(w, "Not found in trash", http.StatusNotFound)
+			return
+		case errors.Is(err, repositories.ErrCategoryHasChildren):
+			h.respondError(w, "Category has subcategories; purge them first", http.StatusConflict)
+			return
+		default:
+			h.logger.Errorw("Failed to purge", "kind", kind, "id", id, "error", err)
+			h.respondError(w, "Failed to purge", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.respondSuccess(w, map[string]string{"message": "Purged"})
+	h.logger.Infow("Purged from trash", "kind", kind, "id", id, "by", claims.Username)
+}
+
+// PurgeExpired permanently deletes everything past the retention period
+// now, instead of waiting for the background purge.
+func (h *TrashHandler) PurgeExpired(w http.ResponseWriter, r *http.Request) {
+	claims := r.Context().Value("user").(*auth.Claims)
+
+	purged, err := h.trashService.PurgeExpired()
+	if err != nil && !errors.Is(err, services.ErrImageCleanup) {
+		h.logger.Errorw("Failed to purge trash", "error", err)
+		h.respondError(w, "Failed to purge trash", http.StatusInternalServerError)
+		return
+	}
+	if err != nil {
+		h.logger.Errorw("Purged, but image cleanup failed", "error", err)
+	}
+
+	h.respondSuccess(w, map[string]int{"purged": purged})
+	h.logger.Infow("Trash purged", "purged", purged, "by", claims.Username)
+}
+
+func (h *TrashHandler) trashItem(w http.ResponseWriter, r *http.Request) (string, int, bool) {
+	vars := mux.Vars(r)
+	kind := vars["kind"]
+	if kind != services.TrashCategories && kind != services.TrashBrands {
+		h.respondError(w, "kind must be 'categories' or 'brands'", http.StatusBadRequest)
+		return "", 0, false
+	}
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.respondError(w, "Invalid ID", http.StatusBadRequest)
+		return "", 0, false
+	}
+	return kind, id, true
+}
+
+func (h *TrashHandler) respondSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (h *TrashHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
@@ -1,22 +1,42 @@
 package handlers
 
 import (
+	"dynamic-ui-backend/internal/auth"
 	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/preview"
 	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/internal/signing"
 	"dynamic-ui-backend/pkg/logger"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
 
+const streamHeartbeatInterval = 20 * time.Second
+
 type UIHandler struct {
 	uiService *services.UIService
+	screens   *screenResolver
+	keyRing   *signing.KeyRing
 	logger    *logger.Logger
 }
 
-func NewUIHandler(uiService *services.UIService, log *logger.Logger) *UIHandler {
-	return &UIHandler{uiService: uiService, logger: log}
+func NewUIHandler(uiService *services.UIService, flagService *services.FlagService, canaryService *services.CanaryService, killSwitchService *services.KillSwitchService, keyRing *signing.KeyRing, log *logger.Logger) *UIHandler {
+	return &UIHandler{
+		uiService: uiService,
+		screens: &screenResolver{
+			uiService:         uiService,
+			flagService:       flagService,
+			canaryService:     canaryService,
+			killSwitchService: killSwitchService,
+			logger:            log,
+		},
+		keyRing: keyRing,
+		logger:  log,
+	}
 }
 
 func (h *UIHandler) GetScreen(w http.ResponseWriter, r *http.Request) {
@@ -51,7 +71,8 @@ func (h *UIHandler) GetScreen(w http.ResponseWriter, r *http.Request) {
 		version = "v1"
 	}
 
-	schema, err := h.uiService.GetScreenSchema(screenName, version)
+	client := clientContextFromRequest(r)
+	schema, revision, err := h.screens.resolve(screenName, version, client)
 	if err != nil {
 		h.logger.Errorw("Failed to get schema", "screen", screenName, "version", version, "error", err)
 		w.Header().Set("Content-Type", "application/json")
@@ -64,17 +85,98 @@ func (h *UIHandler) GetScreen(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	policy, allowed := h.authorizeScreen(w, r, schema, screenName)
+	if !allowed {
+		return
+	}
+
+	schema = h.screens.personalize(schema, screenName, client)
+
 	response := models.UISchemaResponse{
 		Success:  true,
 		Data:     schema,
 		Version:  version,
+		Revision: revision,
 		CachedAt: time.Now(),
 	}
 
+	// Signing must stay the last step: the signature covers the exact
+	// document sent to this client.
+	if h.keyRing.Enabled() {
+		signature, err := h.keyRing.Sign(schema)
+		if err != nil {
+			h.logger.Errorw("Failed to sign schema", "screen", screenName, "version", version, "error", err)
+		} else {
+			response.Signature = signature
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
+	if !policy.IsPublic() {
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
 	json.NewEncoder(w).Encode(response)
 }
 
+// authorizeScreen enforces the access policy of the document about to be
+// served, checking the optional bearer token for restricted screens. It
+// writes the 401/403 response itself when access is denied.
+func (h *UIHandler) authorizeScreen(w http.ResponseWriter, r *http.Request, schema map[string]interface{}, screenName string) (services.AccessPolicy, bool) {
+	policy, err := services.ScreenAccess(schema)
+	if err != nil {
+		h.logger.Errorw("Invalid access policy, denying access", "screen", screenName, "error", err)
+		h.respondScreenError(w, http.StatusForbidden, "Access denied", "FORBIDDEN")
+		return policy, false
+	}
+	if policy.IsPublic() {
+		return policy, true
+	}
+
+	token, ok := auth.BearerToken(r.Header.Get("Authorization"))
+	if !ok {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		h.respondScreenError(w, http.StatusUnauthorized, "Authentication required", "UNAUTHORIZED")
+		return policy, false
+	}
+	claims, err := auth.ValidateToken(token)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		h.respondScreenError(w, http.StatusUnauthorized, "Invalid token", "UNAUTHORIZED")
+		return policy, false
+	}
+	if !policy.AllowsRole(claims.Role) {
+		h.logger.Infow("Screen access denied", "screen", screenName, "user", claims.Username, "role", claims.Role)
+		h.respondScreenError(w, http.StatusForbidden, "Access denied", "FORBIDDEN")
+		return policy, false
+	}
+	return policy, true
+}
+
+func (h *UIHandler) respondScreenError(w http.ResponseWriter, status int, message, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Success: false,
+		Error:   message,
+		Code:    code,
+	})
+}
+
+// GetSigningKeys lists the public keys clients use to verify schema signatures.
+func (h *UIHandler) GetSigningKeys(w http.ResponseWriter, r *http.Request) {
+	keys := make([]signing.PublicKey, 0)
+	if h.keyRing != nil {
+		keys = h.keyRing.PublicKeys()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"keys":    keys,
+	})
+}
+
 func (h *UIHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
 	version := r.URL.Query().Get("version")
 	if version == "" {
@@ -122,3 +224,155 @@ func (h *UIHandler) ListScreens(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// PreviewScreen renders a stored screen schema as an approximate HTML mock.
+func (h *UIHandler) PreviewScreen(w http.ResponseWriter, r *http.Request) {
+	screenName := r.URL.Query().Get("screen")
+	if screenName == "" || strings.Contains(screenName, "..") || strings.Contains(screenName, "/") || strings.Contains(screenName, "\\") {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Success: false, Error: "Valid screen parameter is required", Code: "INVALID_PARAMETER"})
+		return
+	}
+
+	version := r.URL.Query().Get("version")
+	if version == "" {
+		version = "v1"
+	}
+
+	schema, err := h.uiService.GetScreenSchema(screenName, version)
+	if err != nil {
+		h.logger.Errorw("Failed to get schema for preview", "screen", screenName, "version", version, "error", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Success: false, Error: "Schema not found", Code: "SCHEMA_NOT_FOUND"})
+		return
+	}
+
+	h.writePreview(w, schema)
+}
+
+// PreviewSchema renders a schema posted in the request body, so unsaved
+// edits can be reviewed before they are written anywhere.
+func (h *UIHandler) PreviewSchema(w http.ResponseWriter, r *http.Request) {
+	var schema map[string]interface{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 5<<20)).Decode(&schema); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Success: false, Error: "Invalid schema JSON", Code: "INVALID_SCHEMA"})
+		return
+	}
+
+	h.writePreview(w, schema)
+}
+
+func (h *UIHandler) writePreview(w http.ResponseWriter, schema map[string]interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.Write([]byte(preview.Render(schema)))
+}
+
+// StreamUpdates is a Server-Sent Events stream of publish/rollback
+// notifications for one version, optionally limited to a set of screens
+// (?screens=home,survey). Clients resume with the Last-Event-ID header; when
+// the requested ID is no longer retained a "resync" event tells them to
+// refetch every screen.
+func (h *UIHandler) StreamUpdates(w http.ResponseWriter, r *http.Request) {
+	version := r.URL.Query().Get("version")
+	if version == "" {
+		version = "v1"
+	}
+
+	screens := make(map[string]bool)
+	for _, name := range strings.Split(r.URL.Query().Get("screens"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			screens[name] = true
+		}
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	var lastID int64
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ErrorResponse{Success: false, Error: "Invalid Last-Event-ID", Code: "INVALID_PARAMETER"})
+			return
+		}
+		lastID = id
+	}
+
+	rc := http.NewResponseController(w)
+	// The stream outlives the server's WriteTimeout.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Errorw("Streaming not supported", "error", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Success: false, Error: "Streaming not supported"})
+		return
+	}
+
+	events := h.uiService.Events()
+	ch, replay, ok := events.Subscribe(lastID)
+	defer events.Unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, "retry: 5000\n\n")
+	if !ok {
+		fmt.Fprintf(w, "event: resync\ndata: {\"version\":%q}\n\n", version)
+	}
+
+	matches := func(e services.SchemaEvent) bool {
+		if e.Version != version {
+			return false
+		}
+		return len(screens) == 0 || screens[e.Screen]
+	}
+	send := func(e services.SchemaEvent) error {
+		data, _ := json.Marshal(e)
+		_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+		return err
+	}
+
+	for _, e := range replay {
+		if matches(e) {
+			send(e)
+		}
+	}
+	rc.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, open := <-ch:
+			if !open {
+				return
+			}
+			if !matches(e) {
+				continue
+			}
+			if err := send(e); err != nil {
+				return
+			}
+			rc.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			rc.Flush()
+		}
+	}
+}
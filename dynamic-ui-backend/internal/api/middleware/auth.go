@@ -6,7 +6,6 @@ import (
 	"dynamic-ui-backend/internal/models"
 	"encoding/json"
 	"net/http"
-	"strings"
 )
 
 func AuthMiddleware(next http.Handler) http.Handler {
@@ -17,13 +16,13 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
+		token, ok := auth.BearerToken(authHeader)
+		if !ok {
 			respondError(w, "Invalid authorization format", http.StatusUnauthorized)
 			return
 		}
 
-		claims, err := auth.ValidateToken(parts[1])
+		claims, err := auth.ValidateToken(token)
 		if err != nil {
 			respondError(w, "Invalid token", http.StatusUnauthorized)
 			return
@@ -6,6 +6,7 @@ import (
 	"dynamic-ui-backend/internal/database"
 	"dynamic-ui-backend/internal/repositories"
 	"dynamic-ui-backend/internal/services"
+	"dynamic-ui-backend/internal/signing"
 	"dynamic-ui-backend/pkg/logger"
 	"net/http"
 	"os"
@@ -13,20 +14,52 @@ import (
 	"github.com/gorilla/mux"
 )
 
-func SetupRoutes(db *database.DB, uiService *services.UIService, log *logger.Logger) *mux.Router {
+func SetupRoutes(db *database.DB, uiService *services.UIService, keyRing *signing.KeyRing, guard *services.SubmissionGuard, trashService *services.TrashService, log *logger.Logger) *mux.Router {
 	router := mux.NewRouter()
 
 	// Repositories
 	userRepo := repositories.NewUserRepository(db)
 	categoryRepo := repositories.NewCategoryRepository(db)
 	brandRepo := repositories.NewBrandRepository(db)
+	flagRepo := repositories.NewFlagRepository(db)
+	assetRepo := repositories.NewAssetRepository(db)
+	canaryRepo := repositories.NewCanaryRepository(db)
+	killSwitchRepo := repositories.NewKillSwitchRepository(db)
+	feedbackRepo := repositories.NewFeedbackRepository(db)
+	formRepo := repositories.NewFormRepository(db)
+	blockedSubmitterRepo := repositories.NewBlockedSubmitterRepository(db)
+	brandCategoryRepo := repositories.NewBrandCategoryRepository(db)
+
+	// Services
+	archiveService := services.NewArchiveService(uiService)
+	flagService := services.NewFlagService(flagRepo)
+	assetService := services.NewAssetService(assetRepo)
+	validationService := services.NewValidationService(uiService, assetRepo)
+	bundleService := services.NewBundleService(uiService)
+	canaryService := services.NewCanaryService(canaryRepo, uiService)
+	killSwitchService := services.NewKillSwitchService(killSwitchRepo)
+	templateService := services.NewTemplateService(uiService, validationService)
+	feedbackService := services.NewFeedbackService(feedbackRepo, uiService)
+	formService := services.NewFormService(formRepo)
 
 	// Handlers
-	uiHandler := handlers.NewUIHandler(uiService, log)
+	uiHandler := handlers.NewUIHandler(uiService, flagService, canaryService, killSwitchService, keyRing, log)
+	schemaHandler := handlers.NewSchemaHandler(uiService, archiveService, validationService, log)
 	healthHandler := handlers.NewHealthHandler()
 	authHandler := handlers.NewAuthHandler(userRepo, log)
 	adminHandler := handlers.NewAdminHandler(categoryRepo, brandRepo, log)
 	uploadHandler := handlers.NewUploadHandler(log)
+	flagHandler := handlers.NewFlagHandler(flagRepo, flagService, log)
+	assetHandler := handlers.NewAssetHandler(assetRepo, assetService, log)
+	bundleHandler := handlers.NewBundleHandler(bundleService, log)
+	canaryHandler := handlers.NewCanaryHandler(canaryRepo, canaryService, guard, log)
+	killSwitchHandler := handlers.NewKillSwitchHandler(killSwitchRepo, killSwitchService, log)
+	templateHandler := handlers.NewTemplateHandler(templateService, log)
+	feedbackHandler := handlers.NewFeedbackHandler(uiService, flagService, canaryService, killSwitchService, feedbackService, guard, log)
+	formHandler := handlers.NewFormHandler(formRepo, formService, uiService, flagService, canaryService, killSwitchService, guard, log)
+	submitterHandler := handlers.NewSubmitterHandler(blockedSubmitterRepo, guard, log)
+	brandCategoryHandler := handlers.NewBrandCategoryHandler(brandCategoryRepo, categoryRepo, brandRepo, log)
+	trashHandler := handlers.NewTrashHandler(trashService, log)
 
 	// Global middleware
 	router.Use(middleware.CORS)
@@ -53,10 +86,29 @@ func SetupRoutes(db *database.DB, uiService *services.UIService, log *logger.Log
 	// UI Schema (public)
 	api.HandleFunc("/ui", uiHandler.GetScreen).Methods("GET")
 	api.HandleFunc("/ui/version", uiHandler.GetVersion).Methods("GET")
+	api.HandleFunc("/ui/stream", uiHandler.StreamUpdates).Methods("GET")
+	api.HandleFunc("/ui/keys", uiHandler.GetSigningKeys).Methods("GET")
+	api.HandleFunc("/ui/bundle/status", bundleHandler.GetBundleStatus).Methods("GET")
+	api.HandleFunc("/ui/errors", canaryHandler.ReportRenderError).Methods("POST")
+
+	// Survey feedback (public; a bearer token is optional)
+	api.HandleFunc("/feedback", feedbackHandler.SubmitFeedback).Methods("POST")
+
+	// Server-driven forms (public; a bearer token is optional)
+	api.HandleFunc("/forms", formHandler.SubmitForm).Methods("POST")
+
+	// Remote config (public)
+	api.HandleFunc("/flags", flagHandler.GetClientFlags).Methods("GET")
+
+	// Schema assets (public)
+	api.HandleFunc("/assets", assetHandler.GetAssets).Methods("GET")
+	api.HandleFunc("/assets/{kind}/{name}", assetHandler.GetAsset).Methods("GET")
 
 	// Content endpoints (public - for mobile app)
 	api.HandleFunc("/content/categories", adminHandler.GetAllCategories).Methods("GET")
 	api.HandleFunc("/content/brands", adminHandler.GetAllBrands).Methods("GET")
+	api.HandleFunc("/content/categories/{id}/brands", brandCategoryHandler.GetCategoryBrands).Methods("GET")
+	api.HandleFunc("/content/brands/{id}/categories", brandCategoryHandler.GetBrandCategories).Methods("GET")
 
 	// Protected routes
 	protected := api.PathPrefix("").Subrouter()
@@ -73,17 +125,86 @@ func SetupRoutes(db *database.DB, uiService *services.UIService, log *logger.Log
 	admin.HandleFunc("/upload", uploadHandler.UploadImage).Methods("POST")
 
 	// Categories management
-	admin.HandleFunc("/categories", adminHandler.GetAllCategories).Methods("GET")
+	admin.HandleFunc("/categories", adminHandler.ListCategories).Methods("GET")
 	admin.HandleFunc("/categories", adminHandler.CreateCategory).Methods("POST")
+	admin.HandleFunc("/categories/tree", adminHandler.GetCategoryTree).Methods("GET")
+	admin.HandleFunc("/categories/order", adminHandler.GetCategoryOrder).Methods("GET")
+	admin.HandleFunc("/categories/order", adminHandler.ReorderCategories).Methods("PUT")
 	admin.HandleFunc("/categories/{id}", adminHandler.GetCategory).Methods("GET")
 	admin.HandleFunc("/categories/{id}", adminHandler.UpdateCategory).Methods("PUT")
 	admin.HandleFunc("/categories/{id}", adminHandler.DeleteCategory).Methods("DELETE")
+	admin.HandleFunc("/categories/{id}/tree", adminHandler.GetCategorySubtree).Methods("GET")
+	admin.HandleFunc("/categories/{id}/move", adminHandler.MoveCategory).Methods("PUT")
 
 	// Brands management
-	admin.HandleFunc("/brands", adminHandler.GetAllBrands).Methods("GET")
+	admin.HandleFunc("/brands", adminHandler.ListBrands).Methods("GET")
 	admin.HandleFunc("/brands", adminHandler.CreateBrand).Methods("POST")
+	admin.HandleFunc("/brands/order", adminHandler.GetBrandOrder).Methods("GET")
+	admin.HandleFunc("/brands/order", adminHandler.ReorderBrands).Methods("PUT")
 	admin.HandleFunc("/brands/{id}", adminHandler.UpdateBrand).Methods("PUT")
 	admin.HandleFunc("/brands/{id}", adminHandler.DeleteBrand).Methods("DELETE")
+	admin.HandleFunc("/brands/{id}/categories/{category_id}", brandCategoryHandler.AttachCategory).Methods("PUT")
+	admin.HandleFunc("/brands/{id}/categories/{category_id}", brandCategoryHandler.DetachCategory).Methods("DELETE")
+
+	// Trash
+	admin.HandleFunc("/trash", trashHandler.GetTrash).Methods("GET")
+	admin.HandleFunc("/trash/purge", trashHandler.PurgeExpired).Methods("POST")
+	admin.HandleFunc("/trash/{kind}/{id}/restore", trashHandler.RestoreItem).Methods("POST")
+	admin.HandleFunc("/trash/{kind}/{id}", trashHandler.PurgeItem).Methods("DELETE")
+
+	// UI schema tooling
+	admin.HandleFunc("/ui/preview", uiHandler.PreviewScreen).Methods("GET")
+	admin.HandleFunc("/ui/preview", uiHandler.PreviewSchema).Methods("POST")
+	admin.HandleFunc("/ui/export", schemaHandler.Export).Methods("GET")
+	admin.HandleFunc("/ui/import", schemaHandler.Import).Methods("POST")
+	admin.HandleFunc("/ui/drafts", schemaHandler.ListDrafts).Methods("GET")
+	admin.HandleFunc("/ui/drafts/publish", schemaHandler.PublishDraft).Methods("POST")
+	admin.HandleFunc("/ui/revisions", schemaHandler.ListRevisions).Methods("GET")
+	admin.HandleFunc("/ui/rollback", schemaHandler.Rollback).Methods("POST")
+	admin.HandleFunc("/ui/validate", schemaHandler.ValidateScreens).Methods("GET")
+	admin.HandleFunc("/ui/validate", schemaHandler.ValidateDocument).Methods("POST")
+	admin.HandleFunc("/ui/bundle", bundleHandler.DownloadBundle).Methods("GET")
+	admin.HandleFunc("/ui/templates", templateHandler.ListTemplates).Methods("GET")
+	admin.HandleFunc("/ui/templates/instantiate", templateHandler.InstantiateTemplate).Methods("POST")
+	admin.HandleFunc("/ui/clone", templateHandler.CloneScreen).Methods("POST")
+
+	// Canary rollouts
+	admin.HandleFunc("/ui/canaries", canaryHandler.GetCanaries).Methods("GET")
+	admin.HandleFunc("/ui/canaries", canaryHandler.StartCanary).Methods("POST")
+	admin.HandleFunc("/ui/canaries/{id}", canaryHandler.UpdateCanary).Methods("PUT")
+	admin.HandleFunc("/ui/canaries/{id}/promote", canaryHandler.PromoteCanary).Methods("POST")
+	admin.HandleFunc("/ui/canaries/{id}/abort", canaryHandler.AbortCanary).Methods("POST")
+
+	// Widget kill switches
+	admin.HandleFunc("/ui/kill-switches", killSwitchHandler.GetAllKillSwitches).Methods("GET")
+	admin.HandleFunc("/ui/kill-switches", killSwitchHandler.CreateKillSwitch).Methods("POST")
+	admin.HandleFunc("/ui/kill-switches/{id}", killSwitchHandler.UpdateKillSwitch).Methods("PUT")
+	admin.HandleFunc("/ui/kill-switches/{id}", killSwitchHandler.DeleteKillSwitch).Methods("DELETE")
+
+	// Asset registry
+	admin.HandleFunc("/assets", assetHandler.GetAssets).Methods("GET")
+	admin.HandleFunc("/assets", assetHandler.UploadAsset).Methods("POST")
+	admin.HandleFunc("/assets/{kind}/{name}/versions", assetHandler.GetAssetVersions).Methods("GET")
+	admin.HandleFunc("/assets/{kind}/{name}/versions/{version}", assetHandler.SetAssetActive).Methods("PUT")
+
+	// Survey feedback
+	admin.HandleFunc("/feedback/summary", feedbackHandler.GetFeedbackSummary).Methods("GET")
+	admin.HandleFunc("/feedback/export", feedbackHandler.ExportFeedback).Methods("GET")
+
+	// Form submissions
+	admin.HandleFunc("/forms/{form_id}/submissions", formHandler.GetSubmissions).Methods("GET")
+
+	// Submission abuse protection
+	admin.HandleFunc("/submitters/blocked", submitterHandler.GetBlockedSubmitters).Methods("GET")
+	admin.HandleFunc("/submitters/blocked", submitterHandler.BlockSubmitter).Methods("POST")
+	admin.HandleFunc("/submitters/blocked/{id}", submitterHandler.UnblockSubmitter).Methods("DELETE")
+
+	// Feature flags management
+	admin.HandleFunc("/flags", flagHandler.GetAllFlags).Methods("GET")
+	admin.HandleFunc("/flags", flagHandler.CreateFlag).Methods("POST")
+	admin.HandleFunc("/flags/{key}", flagHandler.GetFlag).Methods("GET")
+	admin.HandleFunc("/flags/{key}", flagHandler.UpdateFlag).Methods("PUT")
+	admin.HandleFunc("/flags/{key}", flagHandler.DeleteFlag).Methods("DELETE")
 
 	admin.HandleFunc("/cache/clear", uiHandler.ClearCache).Methods("POST")
 
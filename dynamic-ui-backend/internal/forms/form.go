@@ -0,0 +1,264 @@
+// Package forms derives server-side form definitions from the input widgets
+// of a screen schema and validates submitted values against them, so a new
+// form needs a schema and no backend code.
+//
+// A form is collected by a submit action; its fields are the inputs bound to
+// the action's include_state keys:
+//
+//	{"type": "text_field", "state_key": "phone",
+//	 "validation": {"required": true, "pattern": "^\\+998[0-9]{9}$", "message": "Enter a phone number"}}
+//	{"type": "button", "text": "Send",
+//	 "action": {"type": "submit_form", "form_id": "lead_capture", "include_state": ["name", "phone"]}}
+//
+// The "validation" block accepts required, pattern, min, max, options and
+// message. What min and max bound depends on the widget: the text length
+// for a text_field, the rating for a rating_bar and the number of selected
+// options for a survey_group. A survey_group's choices are its own options;
+// "options" restricts a text_field to a fixed list.
+package forms
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+
+	"dynamic-ui-backend/internal/validation"
+)
+
+// Submit action types.
+const (
+	ActionSubmitForm     = "submit_form"
+	ActionSubmitFeedback = "submit_feedback"
+)
+
+// MaxTextLength caps every text value, whatever the schema allows.
+const MaxTextLength = 5000
+
+// Input widget types that bind a form field.
+var inputWidgets = map[string]bool{"survey_group": true, "rating_bar": true, "text_field": true}
+
+// Field is one validated input of a form.
+type Field struct {
+	Key      string
+	Widget   string
+	Required bool
+	Pattern  *regexp.Regexp
+	Min      *float64
+	Max      *float64
+	Options  []string
+	// Labels maps survey option values to their display text.
+	Labels   map[string]string
+	Multiple bool
+	// Message replaces the default error messages when set.
+	Message string
+}
+
+// Form is what the submit actions sharing a form ID collect.
+type Form struct {
+	ID string
+	// Fields are the inputs bound to declared keys, in document order.
+	Fields []Field
+	// Extra holds declared keys with no input widget. Their values are
+	// accepted as plain strings, numbers or booleans.
+	Extra map[string]bool
+}
+
+// Parse returns the forms collected by the screen's actions of the given
+// type, by form ID. Actions without a "form_id" share the "" form. Invalid
+// validation rules on a bound input are an error.
+func Parse(schema map[string]interface{}, action string) (map[string]*Form, error) {
+	declared := make(map[string]map[string]bool)
+	inputs := make(map[string]map[string]interface{})
+	order := make([]string, 0)
+
+	validation.Walk(schema, func(_ string, node map[string]interface{}, _ []map[string]interface{}) {
+		if a, ok := node["action"].(map[string]interface{}); ok && a["type"] == action {
+			id, _ := a["form_id"].(string)
+			if declared[id] == nil {
+				declared[id] = make(map[string]bool)
+			}
+			for _, key := range IncludeState(a) {
+				declared[id][key] = true
+			}
+		}
+		if typ, _ := node["type"].(string); inputWidgets[typ] {
+			if key, _ := node["state_key"].(string); key != "" {
+				if _, seen := inputs[key]; !seen {
+					order = append(order, key)
+				}
+				inputs[key] = node
+			}
+		}
+	})
+
+	forms := make(map[string]*Form, len(declared))
+	for id, keys := range declared {
+		form := &Form{ID: id, Extra: make(map[string]bool)}
+		for _, key := range order {
+			if !keys[key] {
+				continue
+			}
+			field, err := FieldOf(inputs[key])
+			if err != nil {
+				return nil, fmt.Errorf("field '%s': %w", key, err)
+			}
+			form.Fields = append(form.Fields, field)
+		}
+		for key := range keys {
+			if _, ok := inputs[key]; !ok {
+				form.Extra[key] = true
+			}
+		}
+		forms[id] = form
+	}
+	return forms, nil
+}
+
+// IncludeState returns the state keys a submit action sends.
+func IncludeState(action map[string]interface{}) []string {
+	list, _ := action["include_state"].([]interface{})
+	keys := make([]string, 0, len(list))
+	for _, k := range list {
+		if key, ok := k.(string); ok && key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Keys lists the keys a form accepts: its fields in document order, then
+// the extra keys sorted.
+func (f *Form) Keys() []string {
+	keys := make([]string, 0, len(f.Fields)+len(f.Extra))
+	for _, field := range f.Fields {
+		keys = append(keys, field.Key)
+	}
+	extra := make([]string, 0, len(f.Extra))
+	for key := range f.Extra {
+		extra = append(extra, key)
+	}
+	sort.Strings(extra)
+	return append(keys, extra...)
+}
+
+// Field returns the field bound to a key.
+func (f *Form) Field(key string) (Field, bool) {
+	for _, field := range f.Fields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// FieldOf reads the field an input widget defines, applying the widget's
+// own limits where the validation block sets none.
+func FieldOf(node map[string]interface{}) (Field, error) {
+	field := Field{}
+	field.Key, _ = node["state_key"].(string)
+	field.Widget, _ = node["type"].(string)
+
+	rules := map[string]interface{}{}
+	if v, ok := node["validation"]; ok {
+		if rules, ok = v.(map[string]interface{}); !ok {
+			return field, fmt.Errorf("validation must be an object")
+		}
+	}
+
+	field.Required, _ = rules["required"].(bool)
+	field.Message, _ = rules["message"].(string)
+	if pattern, ok := rules["pattern"].(string); ok && pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return field, fmt.Errorf("invalid pattern: %w", err)
+		}
+		field.Pattern = re
+	}
+	for name, dst := range map[string]**float64{"min": &field.Min, "max": &field.Max} {
+		if v, ok := rules[name]; ok {
+			n, ok := v.(float64)
+			if !ok {
+				return field, fmt.Errorf("%s must be a number", name)
+			}
+			*dst = &n
+		}
+	}
+	if field.Min != nil && field.Max != nil && *field.Min > *field.Max {
+		return field, fmt.Errorf("min is greater than max")
+	}
+
+	switch field.Widget {
+	case "survey_group":
+		single, _ := node["single_selection"].(bool)
+		field.Multiple = !single
+		field.Options = optionValues(node["options"], "value")
+		field.Labels = optionLabels(node["options"])
+	case "rating_bar":
+		if field.Min == nil {
+			field.Min = floatPtr(1)
+		}
+		if field.Max == nil {
+			maxRating := 5.0
+			if m, ok := node["max_rating"].(float64); ok && m >= 1 {
+				maxRating = m
+			}
+			field.Max = &maxRating
+		}
+	case "text_field":
+		if list, ok := rules["options"]; ok {
+			field.Options = optionValues(list, "")
+		}
+		if field.Max == nil {
+			if m, ok := node["max_length"].(float64); ok && m >= 1 {
+				field.Max = &m
+			}
+		}
+		if field.Max == nil || *field.Max > MaxTextLength {
+			field.Max = floatPtr(MaxTextLength)
+		}
+		if field.Min != nil && *field.Min != math.Trunc(*field.Min) {
+			return field, fmt.Errorf("min must be a whole number")
+		}
+	}
+	return field, nil
+}
+
+// optionValues reads a list of option values, either plain strings or
+// objects holding the value under key.
+func optionValues(v interface{}, key string) []string {
+	list, _ := v.([]interface{})
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		switch o := item.(type) {
+		case string:
+			values = append(values, o)
+		case map[string]interface{}:
+			if key != "" {
+				if s, ok := o[key].(string); ok {
+					values = append(values, s)
+				}
+			}
+		}
+	}
+	return values
+}
+
+func optionLabels(v interface{}) map[string]string {
+	list, _ := v.([]interface{})
+	labels := make(map[string]string, len(list))
+	for _, item := range list {
+		if o, ok := item.(map[string]interface{}); ok {
+			value, _ := o["value"].(string)
+			text, _ := o["text"].(string)
+			if value != "" && text != "" {
+				labels[value] = text
+			}
+		}
+	}
+	return labels
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,53 @@
+package forms
+
+import (
+	"fmt"
+
+	"dynamic-ui-backend/internal/validation"
+)
+
+// Rule is the schema lint for forms: invalid validation blocks, submit_form
+// actions without a form_id, and declared keys no input is bound to.
+type Rule struct{}
+
+func (Rule) Name() string { return "forms" }
+
+func (r Rule) Check(doc map[string]interface{}) []validation.Issue {
+	issues := make([]validation.Issue, 0)
+	inputs := make(map[string]bool)
+	type submit struct {
+		path string
+		keys []string
+	}
+	submits := make([]submit, 0)
+
+	validation.Walk(doc, func(path string, node map[string]interface{}, _ []map[string]interface{}) {
+		if typ, _ := node["type"].(string); inputWidgets[typ] {
+			if key, _ := node["state_key"].(string); key != "" {
+				inputs[key] = true
+			}
+			if _, err := FieldOf(node); err != nil {
+				issues = append(issues, validation.Issue{Path: path + ".validation", Rule: r.Name(), Severity: validation.SeverityError, Message: err.Error()})
+			}
+		}
+
+		action, ok := node["action"].(map[string]interface{})
+		if !ok || action["type"] != ActionSubmitForm {
+			return
+		}
+		if id, _ := action["form_id"].(string); id == "" {
+			issues = append(issues, validation.Issue{Path: path + ".action", Rule: r.Name(), Severity: validation.SeverityError, Message: "submit_form action has no form_id"})
+		}
+		submits = append(submits, submit{path: path + ".action", keys: IncludeState(action)})
+	})
+
+	for _, s := range submits {
+		for _, key := range s.keys {
+			if !inputs[key] {
+				issues = append(issues, validation.Issue{Path: s.path, Rule: r.Name(), Severity: validation.SeverityWarning,
+					Message: fmt.Sprintf("'%s' has no input widget; its value is accepted unchecked", key)})
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,204 @@
+package forms
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"unicode/utf8"
+)
+
+// Field error codes, stable for the app to map to its own messages.
+const (
+	CodeUnknown   = "unknown"
+	CodeRequired  = "required"
+	CodeEmpty     = "empty"
+	CodeType      = "type"
+	CodeOption    = "option"
+	CodeDuplicate = "duplicate"
+	CodePattern   = "pattern"
+	CodeMin       = "min"
+	CodeMax       = "max"
+)
+
+// FieldError is a submitted value the form does not accept. Field is empty
+// for errors about the submission as a whole.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Validate checks submitted values against the form and returns the values
+// to store. Null, empty strings and empty lists count as unanswered and are
+// dropped. Keys the form does not declare are rejected, and a submission
+// with no answer at all is an error.
+func (f *Form) Validate(values map[string]interface{}) (map[string]interface{}, []FieldError) {
+	errs := make([]FieldError, 0)
+	accepted := make(map[string]interface{}, len(values))
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := f.Field(key); !ok && !f.Extra[key] {
+			errs = append(errs, FieldError{Field: key, Code: CodeUnknown, Message: "not part of this form"})
+		}
+	}
+
+	for _, field := range f.Fields {
+		value := values[field.Key]
+		if isEmpty(value) {
+			if field.Required {
+				errs = append(errs, *field.error(CodeRequired, "is required"))
+			}
+			continue
+		}
+		v, err := field.check(value)
+		if err != nil {
+			errs = append(errs, *err)
+			continue
+		}
+		accepted[field.Key] = v
+	}
+
+	for _, key := range keys {
+		value := values[key]
+		if !f.Extra[key] || isEmpty(value) {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if utf8.RuneCountInString(v) > MaxTextLength {
+				errs = append(errs, FieldError{Field: key, Code: CodeMax, Message: fmt.Sprintf("is longer than %d characters", MaxTextLength)})
+				continue
+			}
+		case float64, bool:
+		default:
+			errs = append(errs, FieldError{Field: key, Code: CodeType, Message: "must be a string, number or boolean"})
+			continue
+		}
+		accepted[key] = value
+	}
+
+	if len(errs) == 0 && len(accepted) == 0 {
+		errs = append(errs, FieldError{Code: CodeEmpty, Message: "at least one answer is required"})
+	}
+	return accepted, errs
+}
+
+func isEmpty(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case []interface{}:
+		return len(t) == 0
+	}
+	return false
+}
+
+// error builds an error for the field; the schema's message, when set,
+// replaces the default one.
+func (f Field) error(code, message string) *FieldError {
+	if f.Message != "" {
+		message = f.Message
+	}
+	return &FieldError{Field: f.Key, Code: code, Message: message}
+}
+
+func (f Field) check(value interface{}) (interface{}, *FieldError) {
+	switch f.Widget {
+	case "survey_group":
+		return f.checkSelection(value)
+	case "rating_bar":
+		return f.checkRating(value)
+	default:
+		return f.checkText(value)
+	}
+}
+
+func (f Field) checkSelection(value interface{}) (interface{}, *FieldError) {
+	if !f.Multiple {
+		choice, ok := value.(string)
+		if !ok {
+			return nil, f.error(CodeType, "must be one option value")
+		}
+		if !f.isOption(choice) {
+			return nil, f.error(CodeOption, fmt.Sprintf("'%s' is not an option", choice))
+		}
+		return choice, nil
+	}
+
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, f.error(CodeType, "must be a list of option values")
+	}
+	seen := make(map[string]bool, len(list))
+	for _, v := range list {
+		choice, ok := v.(string)
+		if !ok {
+			return nil, f.error(CodeType, "must be a list of option values")
+		}
+		if !f.isOption(choice) {
+			return nil, f.error(CodeOption, fmt.Sprintf("'%s' is not an option", choice))
+		}
+		if seen[choice] {
+			return nil, f.error(CodeDuplicate, fmt.Sprintf("'%s' is selected more than once", choice))
+		}
+		seen[choice] = true
+	}
+	if err := f.checkBounds(float64(len(list)), "select at least %g", "select at most %g"); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (f Field) checkRating(value interface{}) (interface{}, *FieldError) {
+	rating, ok := value.(float64)
+	if !ok || rating != math.Trunc(rating) {
+		return nil, f.error(CodeType, "must be a whole number")
+	}
+	if err := f.checkBounds(rating, "must be at least %g", "must be at most %g"); err != nil {
+		return nil, err
+	}
+	return int(rating), nil
+}
+
+func (f Field) checkText(value interface{}) (interface{}, *FieldError) {
+	text, ok := value.(string)
+	if !ok {
+		return nil, f.error(CodeType, "must be text")
+	}
+	if err := f.checkBounds(float64(utf8.RuneCountInString(text)), "must be at least %g characters", "must be at most %g characters"); err != nil {
+		return nil, err
+	}
+	if len(f.Options) > 0 && !f.isOption(text) {
+		return nil, f.error(CodeOption, fmt.Sprintf("'%s' is not an option", text))
+	}
+	if f.Pattern != nil && !f.Pattern.MatchString(text) {
+		return nil, f.error(CodePattern, "has an invalid format")
+	}
+	return text, nil
+}
+
+func (f Field) checkBounds(n float64, below, above string) *FieldError {
+	if f.Min != nil && n < *f.Min {
+		return f.error(CodeMin, fmt.Sprintf(below, *f.Min))
+	}
+	if f.Max != nil && n > *f.Max {
+		return f.error(CodeMax, fmt.Sprintf(above, *f.Max))
+	}
+	return nil
+}
+
+func (f Field) isOption(value string) bool {
+	for _, o := range f.Options {
+		if o == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+const (
+	AssetKindLottie = "lottie"
+	AssetKindIcon   = "icon"
+)
+
+type Asset struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Kind        string    `json:"kind"`
+	Version     int       `json:"version"`
+	URL         string    `json:"url"`
+	ContentHash string    `json:"content_hash"`
+	SizeBytes   int64     `json:"size_bytes"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   *int      `json:"created_by,omitempty"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Canary rollout states.
+const (
+	CanaryStatusActive   = "active"
+	CanaryStatusPromoted = "promoted"
+	CanaryStatusHalted   = "halted"
+	CanaryStatusAborted  = "aborted"
+)
+
+// SchemaCanary is a candidate screen document served to Percentage of
+// devices until it is promoted, aborted or halted by client error reports.
+type SchemaCanary struct {
+	ID             int       `json:"id"`
+	Version        string    `json:"version"`
+	Screen         string    `json:"screen"`
+	Revision       string    `json:"revision"`
+	Document       string    `json:"-"`
+	Percentage     int       `json:"percentage"`
+	ErrorThreshold int       `json:"error_threshold"`
+	ErrorCount     int       `json:"error_count"`
+	Status         string    `json:"status"`
+	HaltReason     string    `json:"halt_reason,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	CreatedBy      *int      `json:"created_by,omitempty"`
+	UpdatedBy      *int      `json:"updated_by,omitempty"`
+}
+
+type StartCanaryRequest struct {
+	Version        string `json:"version"`
+	Screen         string `json:"screen"`
+	Percentage     int    `json:"percentage"`
+	ErrorThreshold int    `json:"error_threshold"`
+}
+
+type UpdateCanaryRequest struct {
+	Percentage     *int `json:"percentage,omitempty"`
+	ErrorThreshold *int `json:"error_threshold,omitempty"`
+}
+
+// RenderErrorReport is sent by the app when it fails to render a schema
+// revision it was served.
+type RenderErrorReport struct {
+	Version  string `json:"version"`
+	Screen   string `json:"screen"`
+	Revision string `json:"revision"`
+	Message  string `json:"message"`
+}
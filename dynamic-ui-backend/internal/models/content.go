@@ -2,33 +2,43 @@ package models
 
 import "time"
 
+// Category is a node of the catalog tree. ParentID is nil for top-level
+// departments; Children is only filled in tree responses. DeletedAt is set
+// while the category is in the trash.
 type Category struct {
-	ID           int       `json:"id"`
-	Name         string    `json:"name"`
-	SearchText   string    `json:"search_text"`
-	ImageURL     string    `json:"image_url"`
-	DisplayOrder int       `json:"display_order"`
-	IsActive     bool      `json:"is_active"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	CreatedBy    *int      `json:"created_by,omitempty"`
-	UpdatedBy    *int      `json:"updated_by,omitempty"`
+	ID           int        `json:"id"`
+	ParentID     *int       `json:"parent_id"`
+	Name         string     `json:"name"`
+	SearchText   string     `json:"search_text"`
+	ImageURL     string     `json:"image_url"`
+	DisplayOrder int        `json:"display_order"`
+	IsActive     bool       `json:"is_active"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	CreatedBy    *int       `json:"created_by,omitempty"`
+	UpdatedBy    *int       `json:"updated_by,omitempty"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy    *int       `json:"deleted_by,omitempty"`
+	Children     []Category `json:"children,omitempty"`
 }
 
 type Brand struct {
-	ID           int       `json:"id"`
-	Name         string    `json:"name"`
-	SearchText   string    `json:"search_text"`
-	ImageURL     string    `json:"image_url"`
-	DisplayOrder int       `json:"display_order"`
-	IsActive     bool      `json:"is_active"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	CreatedBy    *int      `json:"created_by,omitempty"`
-	UpdatedBy    *int      `json:"updated_by,omitempty"`
+	ID           int        `json:"id"`
+	Name         string     `json:"name"`
+	SearchText   string     `json:"search_text"`
+	ImageURL     string     `json:"image_url"`
+	DisplayOrder int        `json:"display_order"`
+	IsActive     bool       `json:"is_active"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	CreatedBy    *int       `json:"created_by,omitempty"`
+	UpdatedBy    *int       `json:"updated_by,omitempty"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy    *int       `json:"deleted_by,omitempty"`
 }
 
 type CreateCategoryRequest struct {
+	ParentID     *int   `json:"parent_id,omitempty"`
 	Name         string `json:"name"`
 	SearchText   string `json:"search_text"`
 	ImageURL     string `json:"image_url"`
@@ -43,6 +53,13 @@ type UpdateCategoryRequest struct {
 	IsActive     *bool   `json:"is_active,omitempty"`
 }
 
+// MoveCategoryRequest moves a category under another parent (null for the
+// top level), optionally setting its place among the new siblings.
+type MoveCategoryRequest struct {
+	ParentID     *int `json:"parent_id"`
+	DisplayOrder *int `json:"display_order,omitempty"`
+}
+
 type CreateBrandRequest struct {
 	Name         string `json:"name"`
 	SearchText   string `json:"search_text"`
@@ -57,3 +74,46 @@ type UpdateBrandRequest struct {
 	DisplayOrder *int    `json:"display_order,omitempty"`
 	IsActive     *bool   `json:"is_active,omitempty"`
 }
+
+// BrandCategory links a brand to a category it is listed under.
+type BrandCategory struct {
+	BrandID       int       `json:"brand_id"`
+	CategoryID    int       `json:"category_id"`
+	BrandOrder    int       `json:"brand_order"`
+	CategoryOrder int       `json:"category_order"`
+	CreatedAt     time.Time `json:"created_at"`
+	CreatedBy     *int      `json:"created_by,omitempty"`
+}
+
+// AttachBrandCategoryRequest sets the place of the brand within the category
+// and of the category within the brand. Omitted orders append a new link to
+// the end and leave an existing link unchanged.
+type AttachBrandCategoryRequest struct {
+	BrandOrder    *int `json:"brand_order,omitempty"`
+	CategoryOrder *int `json:"category_order,omitempty"`
+}
+
+// Ordering is the current order of a list of categories or brands. The
+// fingerprint has to be sent back with a reorder so that a stale order is
+// rejected instead of overwriting someone else's changes.
+type Ordering struct {
+	IDs         []int  `json:"ids"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// ReorderRequest lists every id of the reordered list in its new order.
+// ParentID selects the siblings being reordered for categories (null for the
+// top level) and is ignored for brands.
+type ReorderRequest struct {
+	ParentID    *int   `json:"parent_id"`
+	IDs         []int  `json:"ids"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Trash lists the deleted categories and brands, most recently deleted
+// first. Entries are purged for good once they are older than the retention.
+type Trash struct {
+	Categories    []Category `json:"categories"`
+	Brands        []Brand    `json:"brands"`
+	RetentionDays int        `json:"retention_days"`
+}
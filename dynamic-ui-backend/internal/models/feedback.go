@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// FeedbackResponse is one submission of a screen's survey inputs. Answers
+// maps each input's state key to the submitted value.
+type FeedbackResponse struct {
+	ID            int             `json:"id"`
+	Screen        string          `json:"screen"`
+	ScreenID      string          `json:"screen_id"`
+	Version       string          `json:"version"`
+	SchemaVersion string          `json:"schema_version"`
+	Revision      string          `json:"revision,omitempty"`
+	Answers       json.RawMessage `json:"answers"`
+	DeviceID      string          `json:"device_id"`
+	Platform      string          `json:"platform,omitempty"`
+	AppVersion    string          `json:"app_version,omitempty"`
+	UserID        *int            `json:"user_id,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// SubmitFeedbackRequest carries the state map collected by a screen's
+// submit_feedback action.
+type SubmitFeedbackRequest struct {
+	Screen  string                 `json:"screen"`
+	Version string                 `json:"version"`
+	State   map[string]interface{} `json:"state"`
+}
+
+// FeedbackFilter narrows the responses an aggregation covers. Empty fields
+// do not filter; To is exclusive.
+type FeedbackFilter struct {
+	Screen     string
+	Version    string
+	From       *time.Time
+	To         *time.Time
+	AppVersion string
+	Platform   string
+}
+
+// FeedbackBucket counts responses submitted in one period.
+type FeedbackBucket struct {
+	Period time.Time `json:"period"`
+	Count  int       `json:"count"`
+}
+
+// FeedbackValueCount counts how often a state key held a value. For
+// multi-selection survey groups each selected option counts once.
+type FeedbackValueCount struct {
+	Key   string
+	Value string
+	Count int
+}
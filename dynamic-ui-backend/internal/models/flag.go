@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Supported feature flag value types.
+const (
+	FlagTypeBoolean = "boolean"
+	FlagTypeInteger = "integer"
+	FlagTypeNumber  = "number"
+	FlagTypeString  = "string"
+	FlagTypeJSON    = "json"
+)
+
+type FeatureFlag struct {
+	ID           int             `json:"id"`
+	Key          string          `json:"key"`
+	Description  string          `json:"description"`
+	ValueType    string          `json:"value_type"`
+	DefaultValue json.RawMessage `json:"default_value"`
+	Rules        []FlagRule      `json:"rules"`
+	IsActive     bool            `json:"is_active"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	CreatedBy    *int            `json:"created_by,omitempty"`
+	UpdatedBy    *int            `json:"updated_by,omitempty"`
+}
+
+// FlagRule overrides the default value for clients matching all of its
+// conditions. Rules are evaluated in order and the first match wins.
+// Percentage limits the rule to a stable share of devices.
+type FlagRule struct {
+	Platform      string          `json:"platform,omitempty"`
+	MinAppVersion string          `json:"min_app_version,omitempty"`
+	MaxAppVersion string          `json:"max_app_version,omitempty"`
+	Percentage    *int            `json:"percentage,omitempty"`
+	Value         json.RawMessage `json:"value"`
+}
+
+type CreateFlagRequest struct {
+	Key          string          `json:"key"`
+	Description  string          `json:"description"`
+	ValueType    string          `json:"value_type"`
+	DefaultValue json.RawMessage `json:"default_value"`
+	Rules        []FlagRule      `json:"rules"`
+}
+
+type UpdateFlagRequest struct {
+	Description  *string         `json:"description,omitempty"`
+	DefaultValue json.RawMessage `json:"default_value,omitempty"`
+	Rules        *[]FlagRule     `json:"rules,omitempty"`
+	IsActive     *bool           `json:"is_active,omitempty"`
+}
+
+// ClientContext identifies the requesting app install for targeting.
+type ClientContext struct {
+	Platform   string `json:"platform"`
+	FormFactor string `json:"form_factor,omitempty"`
+	AppVersion string `json:"app_version"`
+	DeviceID   string `json:"device_id"`
+}
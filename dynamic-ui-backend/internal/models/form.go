@@ -0,0 +1,33 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// FormSubmission is one accepted submission of a server-driven form. Data
+// maps each field's state key to the submitted value.
+type FormSubmission struct {
+	ID            int             `json:"id"`
+	FormID        string          `json:"form_id"`
+	Screen        string          `json:"screen"`
+	ScreenID      string          `json:"screen_id"`
+	Version       string          `json:"version"`
+	SchemaVersion string          `json:"schema_version"`
+	Revision      string          `json:"revision,omitempty"`
+	Data          json.RawMessage `json:"data"`
+	DeviceID      string          `json:"device_id"`
+	Platform      string          `json:"platform,omitempty"`
+	AppVersion    string          `json:"app_version,omitempty"`
+	UserID        *int            `json:"user_id,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// SubmitFormRequest carries the state collected by a screen's submit_form
+// action.
+type SubmitFormRequest struct {
+	Screen  string                 `json:"screen"`
+	Version string                 `json:"version"`
+	FormID  string                 `json:"form_id"`
+	Values  map[string]interface{} `json:"values"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// KillSwitch removes matching widgets from every schema response. A switch
+// matches by widget type, widget id, or both; the platform and app version
+// range narrow it to the affected clients.
+type KillSwitch struct {
+	ID            int       `json:"id"`
+	WidgetType    string    `json:"widget_type,omitempty"`
+	WidgetID      string    `json:"widget_id,omitempty"`
+	Platform      string    `json:"platform,omitempty"`
+	MinAppVersion string    `json:"min_app_version,omitempty"`
+	MaxAppVersion string    `json:"max_app_version,omitempty"`
+	Reason        string    `json:"reason"`
+	IsActive      bool      `json:"is_active"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	CreatedBy     *int      `json:"created_by,omitempty"`
+	UpdatedBy     *int      `json:"updated_by,omitempty"`
+}
+
+type CreateKillSwitchRequest struct {
+	WidgetType    string `json:"widget_type"`
+	WidgetID      string `json:"widget_id"`
+	Platform      string `json:"platform"`
+	MinAppVersion string `json:"min_app_version"`
+	MaxAppVersion string `json:"max_app_version"`
+	Reason        string `json:"reason"`
+}
+
+type UpdateKillSwitchRequest struct {
+	Platform      *string `json:"platform,omitempty"`
+	MinAppVersion *string `json:"min_app_version,omitempty"`
+	MaxAppVersion *string `json:"max_app_version,omitempty"`
+	Reason        *string `json:"reason,omitempty"`
+	IsActive      *bool   `json:"is_active,omitempty"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// ListQuery filters, sorts and pages a category or brand list. A zero Limit
+// returns every match. Cursor and Offset are alternatives: the cursor of a
+// page continues after its last row, whatever was added or removed before it.
+type ListQuery struct {
+	Name        string     `json:"name,omitempty"`
+	IsActive    *bool      `json:"is_active,omitempty"`
+	CreatedFrom *time.Time `json:"created_from,omitempty"`
+	CreatedTo   *time.Time `json:"created_to,omitempty"`
+	UpdatedFrom *time.Time `json:"updated_from,omitempty"`
+	UpdatedTo   *time.Time `json:"updated_to,omitempty"`
+	CreatedBy   *int       `json:"created_by,omitempty"`
+	Sort        string     `json:"sort"`
+	Desc        bool       `json:"desc"`
+	Limit       int        `json:"limit"`
+	Offset      int        `json:"offset"`
+	Cursor      string     `json:"cursor,omitempty"`
+}
+
+// ListMeta describes a page of a list. Total counts every match of the
+// filters; NextCursor is empty on the last page.
+type ListMeta struct {
+	Total      int    `json:"total"`
+	Count      int    `json:"count"`
+	Limit      int    `json:"limit,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
+	Sort       string `json:"sort"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Blocked submitter kinds.
+const (
+	SubmitterDevice = "device"
+	SubmitterIP     = "ip"
+)
+
+// BlockedSubmitter is a device or IP the public submission endpoints refuse.
+// A nil ExpiresAt blocks until an admin removes the entry.
+type BlockedSubmitter struct {
+	ID        int        `json:"id"`
+	Kind      string     `json:"kind"`
+	Value     string     `json:"value"`
+	Reason    string     `json:"reason"`
+	Automatic bool       `json:"automatic"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	CreatedBy *int       `json:"created_by,omitempty"`
+}
+
+// BlockSubmitterRequest blocks a submitter by hand. Hours of 0 blocks until
+// the entry is removed.
+type BlockSubmitterRequest struct {
+	Kind   string `json:"kind"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+	Hours  int    `json:"hours"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// InstantiateTemplateRequest creates a draft screen from a template.
+// Version is the template's version; TargetVersion defaults to it.
+type InstantiateTemplateRequest struct {
+	Template      string                 `json:"template"`
+	Version       string                 `json:"version"`
+	Screen        string                 `json:"screen"`
+	TargetVersion string                 `json:"target_version"`
+	Params        map[string]interface{} `json:"params"`
+	Overwrite     bool                   `json:"overwrite"`
+}
+
+// CloneScreenRequest copies a published screen into a new draft screen.
+// SourceVersion defaults to v1 and Version to SourceVersion.
+type CloneScreenRequest struct {
+	Source        string `json:"source"`
+	SourceVersion string `json:"source_version"`
+	Screen        string `json:"screen"`
+	Version       string `json:"version"`
+	Overwrite     bool   `json:"overwrite"`
+}
@@ -1,13 +1,18 @@
 package models
 
-import "time"
+import (
+	"dynamic-ui-backend/internal/signing"
+	"time"
+)
 
 type UISchemaResponse struct {
-	Success  bool        `json:"success"`
-	Data     interface{} `json:"data,omitempty"`
-	Message  string      `json:"message,omitempty"`
-	Version  string      `json:"version"`
-	CachedAt time.Time   `json:"cached_at"`
+	Success   bool               `json:"success"`
+	Data      interface{}        `json:"data,omitempty"`
+	Message   string             `json:"message,omitempty"`
+	Version   string             `json:"version"`
+	Revision  string             `json:"revision,omitempty"`
+	CachedAt  time.Time          `json:"cached_at"`
+	Signature *signing.Signature `json:"signature,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,668 @@
+package preview
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// Native widgets are drawn by the app itself, so the preview only shows a
+// labelled placeholder of a plausible height for them.
+var nativePlaceholders = map[string]int{
+	"search_category_carousel": 96,
+	"ads_cell":                 140,
+	"category_grid":            220,
+	"brands_carousel":          90,
+	"advised_goods_grid":       260,
+	"platform_goods_masonry":   320,
+	"load_more_button":         48,
+	"search_header":            56,
+	"draggable_ai_fab":         56,
+}
+
+const pageCSS = `
+body { margin: 0; background: #E0E0E0; font-family: -apple-system, Roboto, "Segoe UI", sans-serif; }
+.device { width: 390px; min-height: 780px; margin: 24px auto; position: relative; overflow: hidden; box-shadow: 0 8px 30px rgba(0,0,0,.25); border-radius: 28px; }
+.app-bar { display: flex; align-items: center; padding: 0 16px; height: 56px; font-size: 20px; font-weight: 600; }
+.placeholder { display: flex; align-items: center; justify-content: center; border: 2px dashed #9E9E9E; border-radius: 12px; color: #616161; background: repeating-linear-gradient(45deg, #FAFAFA, #FAFAFA 10px, #F0F0F0 10px, #F0F0F0 20px); font-size: 13px; font-family: monospace; }
+.row { display: flex; flex-direction: row; }
+.column { display: flex; flex-direction: column; }
+.chip { display: flex; align-items: center; gap: 8px; padding: 12px 14px; margin-bottom: 8px; border-radius: 12px; border: 1px solid #2A5F75; }
+.icon { font-family: monospace; font-size: 11px; display: inline-flex; align-items: center; justify-content: center; border-radius: 6px; background: rgba(127,127,127,.2); }
+.badge { font-size: 10px; font-weight: 700; padding: 2px 6px; border-radius: 8px; color: #fff; }
+.fab { position: absolute; right: 16px; bottom: 16px; }
+`
+
+// Render produces a standalone HTML page approximating how the app would
+// lay out the given screen schema.
+func Render(schema map[string]interface{}) string {
+	var b strings.Builder
+
+	title := str(schema, "title")
+	if title == "" {
+		title = str(schema, "screen_id")
+	}
+
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\">")
+	fmt.Fprintf(&b, "<title>Preview: %s</title>", html.EscapeString(title))
+	b.WriteString("<style>" + pageCSS + "</style></head><body>")
+
+	deviceStyle := map[string]string{}
+	if bg := color(schema["background_color"]); bg != "" {
+		deviceStyle["background"] = bg
+	} else {
+		deviceStyle["background"] = "#FFFFFF"
+	}
+	fmt.Fprintf(&b, `<div class="device" style="%s">`, css(deviceStyle))
+
+	if appBar, ok := schema["app_bar"].(map[string]interface{}); ok {
+		renderAppBar(&b, appBar)
+	}
+
+	if widgets, ok := schema["widgets"].([]interface{}); ok {
+		for _, w := range widgets {
+			if node, ok := w.(map[string]interface{}); ok {
+				renderNode(&b, node)
+			}
+		}
+	}
+
+	if fab, ok := schema["floating_action_button"].(map[string]interface{}); ok {
+		b.WriteString(`<div class="fab">`)
+		renderPlaceholder(&b, str(fab, "type"), 56, 56)
+		b.WriteString(`</div>`)
+	}
+
+	b.WriteString("</div></body></html>")
+	return b.String()
+}
+
+func renderAppBar(b *strings.Builder, appBar map[string]interface{}) {
+	if t := str(appBar, "type"); t != "" {
+		if h, native := nativePlaceholders[t]; native {
+			renderPlaceholder(b, t, h, 0)
+			return
+		}
+	}
+
+	style := map[string]string{}
+	if bg := color(appBar["background_color"]); bg != "" {
+		style["background"] = bg
+	}
+	if c := color(appBar["text_color"]); c != "" {
+		style["color"] = c
+	}
+	if g, ok := appBar["title_gradient"].(map[string]interface{}); ok {
+		applyTextGradient(style, g)
+	}
+	fmt.Fprintf(b, `<div class="app-bar"><span style="%s">%s</span></div>`, css(style), html.EscapeString(str(appBar, "title")))
+}
+
+func renderNode(b *strings.Builder, node map[string]interface{}) {
+	widgetType := str(node, "type")
+	style := boxStyle(node)
+
+	if h, native := nativePlaceholders[widgetType]; native {
+		fmt.Fprintf(b, `<div style="%s">`, css(style))
+		renderPlaceholder(b, widgetType, h, 0)
+		b.WriteString(`</div>`)
+		return
+	}
+
+	switch widgetType {
+	case "container":
+		fmt.Fprintf(b, `<div style="%s">`, css(style))
+		renderChildren(b, node)
+		b.WriteString(`</div>`)
+
+	case "row", "column":
+		style["align-items"] = crossAxis(str(node, "cross_axis_alignment"))
+		style["justify-content"] = mainAxis(str(node, "main_axis_alignment"))
+		fmt.Fprintf(b, `<div class="%s" style="%s">`, widgetType, css(style))
+		renderChildren(b, node)
+		b.WriteString(`</div>`)
+
+	case "sized_box":
+		if v, ok := num(node["height"]); ok {
+			style["height"] = px(v)
+		}
+		if v, ok := num(node["width"]); ok {
+			style["width"] = px(v)
+			style["flex-shrink"] = "0"
+		}
+		fmt.Fprintf(b, `<div style="%s"></div>`, css(style))
+
+	case "text", "section_header":
+		content := str(node, "content")
+		if widgetType == "section_header" {
+			content = str(node, "title")
+			style["font-size"] = "18px"
+			style["font-weight"] = "700"
+		}
+		if s, ok := node["style"].(map[string]interface{}); ok {
+			applyTextStyle(style, s)
+		}
+		style["white-space"] = "pre-line"
+		fmt.Fprintf(b, `<div style="%s">%s</div>`, css(style), html.EscapeString(content))
+
+	case "button":
+		renderButton(b, node, style)
+
+	case "icon_widget":
+		size := 24.0
+		if v, ok := num(node["size"]); ok {
+			size = v
+		}
+		renderIcon(b, str(node, "icon"), size, color(node["color"]))
+
+	case "feature_item":
+		renderFeatureItem(b, node, style)
+
+	case "stat_item":
+		fmt.Fprintf(b, `<div class="column" style="align-items:center;%s">`, css(style))
+		renderIcon(b, str(node, "icon"), 28, "#F4D589")
+		fmt.Fprintf(b, `<strong style="color:#FAF1B5;font-size:18px">%s</strong><span style="color:#D1E0E8;font-size:12px">%s</span>`,
+			html.EscapeString(str(node, "value")), html.EscapeString(str(node, "label")))
+		b.WriteString(`</div>`)
+
+	case "survey_group":
+		renderSurveyGroup(b, node, style)
+
+	case "rating_bar":
+		renderRatingBar(b, node, style)
+
+	case "text_field":
+		renderTextField(b, node, style)
+
+	case "lottie":
+		fmt.Fprintf(b, `<div style="%s">`, css(style))
+		renderPlaceholder(b, "lottie: "+str(node, "asset"), 96, 96)
+		b.WriteString(`</div>`)
+
+	default:
+		fmt.Fprintf(b, `<div style="%s">`, css(style))
+		renderPlaceholder(b, widgetType, 48, 0)
+		b.WriteString(`</div>`)
+	}
+}
+
+func renderChildren(b *strings.Builder, node map[string]interface{}) {
+	children, _ := node["children"].([]interface{})
+	for _, c := range children {
+		if child, ok := c.(map[string]interface{}); ok {
+			renderNode(b, child)
+		}
+	}
+	if child, ok := node["child"].(map[string]interface{}); ok {
+		renderNode(b, child)
+	}
+}
+
+func renderButton(b *strings.Builder, node map[string]interface{}, style map[string]string) {
+	style["display"] = "flex"
+	style["align-items"] = "center"
+	style["justify-content"] = "center"
+	style["gap"] = "8px"
+	style["height"] = "48px"
+	if v, ok := num(node["height"]); ok {
+		style["height"] = px(v)
+	}
+	if v, ok := num(node["border_radius"]); ok {
+		style["border-radius"] = px(v)
+	}
+	if bg := color(node["background_color"]); bg != "" {
+		style["background"] = bg
+	}
+	if g, ok := node["background_gradient"].(map[string]interface{}); ok {
+		style["background"] = gradient(g)
+	}
+	if c := color(node["text_color"]); c != "" {
+		style["color"] = c
+	}
+	if v, ok := num(node["font_size"]); ok {
+		style["font-size"] = px(v)
+	}
+	if w := str(node, "font_weight"); w != "" {
+		style["font-weight"] = fontWeight(w)
+	}
+	if sh, ok := node["shadow"].(map[string]interface{}); ok {
+		style["box-shadow"] = shadow(sh)
+	}
+
+	fmt.Fprintf(b, `<div style="%s" title="%s">`, css(style), html.EscapeString(describeAction(node["action"])))
+	if icon := str(node, "icon"); icon != "" {
+		renderIcon(b, icon, 20, "")
+	}
+	b.WriteString(html.EscapeString(str(node, "text")))
+	b.WriteString(`</div>`)
+}
+
+func renderFeatureItem(b *strings.Builder, node map[string]interface{}, style map[string]string) {
+	style["gap"] = "12px"
+	style["align-items"] = "center"
+	fmt.Fprintf(b, `<div class="row" style="%s">`, css(style))
+	renderIcon(b, str(node, "icon"), 32, color(node["icon_color"]))
+	fmt.Fprintf(b, `<div class="column" style="flex:1"><strong style="color:#FAF1B5">%s</strong><span style="color:#D1E0E8;font-size:13px">%s</span></div>`,
+		html.EscapeString(str(node, "title")), html.EscapeString(str(node, "description")))
+	if badge := str(node, "badge"); badge != "" {
+		bg := color(node["badge_color"])
+		if bg == "" {
+			bg = "#757575"
+		}
+		fmt.Fprintf(b, `<span class="badge" style="background:%s">%s</span>`, bg, html.EscapeString(badge))
+	}
+	b.WriteString(`</div>`)
+}
+
+func renderSurveyGroup(b *strings.Builder, node map[string]interface{}, style map[string]string) {
+	marker := "☐"
+	if single, _ := node["single_selection"].(bool); single {
+		marker = "◯"
+	}
+	fmt.Fprintf(b, `<div style="%s" title="state_key: %s">`, css(style), html.EscapeString(str(node, "state_key")))
+	options, _ := node["options"].([]interface{})
+	for _, o := range options {
+		opt, ok := o.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		b.WriteString(`<div class="chip" style="color:#FFFEF0">`)
+		fmt.Fprintf(b, `<span>%s</span>`, marker)
+		if icon := str(opt, "icon"); icon != "" {
+			renderIcon(b, icon, 20, "#F4D589")
+		}
+		b.WriteString(html.EscapeString(str(opt, "text")))
+		b.WriteString(`</div>`)
+	}
+	b.WriteString(`</div>`)
+}
+
+// maxPreviewRating bounds the stars drawn for a rating bar, since the
+// previewed schema comes straight from the request body.
+const maxPreviewRating = 20
+
+func renderRatingBar(b *strings.Builder, node map[string]interface{}, style map[string]string) {
+	maxRating := 5
+	if v, ok := num(node["max_rating"]); ok {
+		maxRating = int(clamp(v, 1, maxPreviewRating))
+	}
+	initial := 0
+	if v, ok := num(node["initial_rating"]); ok {
+		initial = int(clamp(v, 0, float64(maxRating)))
+	}
+	size := 32.0
+	if v, ok := num(node["icon_size"]); ok {
+		size = v
+	}
+	active := color(node["active_color"])
+	if active == "" {
+		active = "#FFC107"
+	}
+	inactive := color(node["inactive_color"])
+	if inactive == "" {
+		inactive = "#BDBDBD"
+	}
+
+	style["justify-content"] = "center"
+	fmt.Fprintf(b, `<div class="row" style="%s">`, css(style))
+	for i := 1; i <= maxRating; i++ {
+		c := inactive
+		if i <= initial {
+			c = active
+		}
+		fmt.Fprintf(b, `<span style="font-size:%s;color:%s">★</span>`, px(size), c)
+	}
+	b.WriteString(`</div>`)
+}
+
+func renderTextField(b *strings.Builder, node map[string]interface{}, style map[string]string) {
+	lines := 1.0
+	if v, ok := num(node["max_lines"]); ok {
+		lines = v
+	}
+	style["min-height"] = px(lines*20 + 24)
+	style["padding"] = "12px"
+	style["border-radius"] = "12px"
+	style["box-sizing"] = "border-box"
+	if bg := color(node["background_color"]); bg != "" {
+		style["background"] = bg
+	}
+	if bc := color(node["border_color"]); bc != "" {
+		style["border"] = "1px solid " + bc
+	}
+	style["color"] = "#9E9E9E"
+	if hc := color(node["hint_color"]); hc != "" {
+		style["color"] = hc
+	}
+	fmt.Fprintf(b, `<div style="%s">%s</div>`, css(style), html.EscapeString(str(node, "hint")))
+}
+
+func renderIcon(b *strings.Builder, name string, size float64, c string) {
+	style := map[string]string{
+		"width":       px(size),
+		"height":      px(size),
+		"flex-shrink": "0",
+	}
+	if c != "" {
+		style["color"] = c
+	}
+	fmt.Fprintf(b, `<span class="icon" style="%s" title="%s">%s</span>`, css(style), html.EscapeString(name), html.EscapeString(iconLabel(name)))
+}
+
+func renderPlaceholder(b *strings.Builder, label string, height, width int) {
+	style := map[string]string{"height": fmt.Sprintf("%dpx", height)}
+	if width > 0 {
+		style["width"] = fmt.Sprintf("%dpx", width)
+	}
+	fmt.Fprintf(b, `<div class="placeholder" style="%s">%s</div>`, css(style), html.EscapeString(label))
+}
+
+// boxStyle translates the layout properties shared by all widgets.
+func boxStyle(node map[string]interface{}) map[string]string {
+	style := map[string]string{}
+
+	if p := edgeInsets(node["padding"]); p != "" {
+		style["padding"] = p
+	}
+	if m := edgeInsets(node["margin"]); m != "" {
+		style["margin"] = m
+	}
+	if v, ok := num(node["flex"]); ok {
+		style["flex"] = fmt.Sprintf("%g", v)
+	}
+
+	if d, ok := node["decoration"].(map[string]interface{}); ok {
+		if bg := color(d["background_color"]); bg != "" {
+			style["background"] = bg
+		}
+		if g, ok := d["background_gradient"].(map[string]interface{}); ok {
+			style["background"] = gradient(g)
+		}
+		if v, ok := num(d["border_radius"]); ok {
+			style["border-radius"] = px(v)
+		}
+		if border, ok := d["border"].(map[string]interface{}); ok {
+			width := 1.0
+			if v, ok := num(border["width"]); ok {
+				width = v
+			}
+			style["border"] = fmt.Sprintf("%s solid %s", px(width), color(border["color"]))
+		}
+		if sh, ok := d["shadow"].(map[string]interface{}); ok {
+			style["box-shadow"] = shadow(sh)
+		}
+	}
+
+	return style
+}
+
+func applyTextStyle(style map[string]string, s map[string]interface{}) {
+	if v, ok := num(s["font_size"]); ok {
+		style["font-size"] = px(v)
+	}
+	if w := str(s, "font_weight"); w != "" {
+		style["font-weight"] = fontWeight(w)
+	}
+	if c := color(s["color"]); c != "" {
+		style["color"] = c
+	}
+	switch a := str(s, "text_align"); a {
+	case "left", "right", "center", "justify", "start", "end":
+		style["text-align"] = a
+	}
+	if v, ok := num(s["height"]); ok {
+		style["line-height"] = fmt.Sprintf("%g", v)
+	}
+	if g, ok := s["gradient"].(map[string]interface{}); ok {
+		applyTextGradient(style, g)
+	}
+}
+
+func applyTextGradient(style map[string]string, g map[string]interface{}) {
+	style["background"] = gradient(g)
+	style["-webkit-background-clip"] = "text"
+	style["background-clip"] = "text"
+	style["-webkit-text-fill-color"] = "transparent"
+}
+
+// edgeInsets accepts both the numeric shorthand ("padding": 14) and the
+// object form ({"all": 14} / {"left": 4, ...}).
+func edgeInsets(v interface{}) string {
+	if n, ok := num(v); ok {
+		return px(n)
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	var top, right, bottom, left float64
+	if all, ok := num(m["all"]); ok {
+		top, right, bottom, left = all, all, all, all
+	}
+	if h, ok := num(m["horizontal"]); ok {
+		left, right = h, h
+	}
+	if vv, ok := num(m["vertical"]); ok {
+		top, bottom = vv, vv
+	}
+	if x, ok := num(m["top"]); ok {
+		top = x
+	}
+	if x, ok := num(m["right"]); ok {
+		right = x
+	}
+	if x, ok := num(m["bottom"]); ok {
+		bottom = x
+	}
+	if x, ok := num(m["left"]); ok {
+		left = x
+	}
+	return fmt.Sprintf("%s %s %s %s", px(top), px(right), px(bottom), px(left))
+}
+
+func gradient(g map[string]interface{}) string {
+	colors := make([]string, 0)
+	if list, ok := g["colors"].([]interface{}); ok {
+		for _, c := range list {
+			if s := color(c); s != "" {
+				colors = append(colors, s)
+			}
+		}
+	}
+	if len(colors) == 0 {
+		return "transparent"
+	}
+	if len(colors) == 1 {
+		colors = append(colors, colors[0])
+	}
+	return fmt.Sprintf("linear-gradient(%s, %s)", direction(str(g, "begin"), str(g, "end")), strings.Join(colors, ", "))
+}
+
+// direction maps Flutter's Alignment begin/end pair to a CSS gradient
+// direction. Only the end point matters for CSS; begin is used as a fallback.
+func direction(begin, end string) string {
+	names := map[string]string{
+		"topLeft":      "to top left",
+		"topCenter":    "to top",
+		"topRight":     "to top right",
+		"centerLeft":   "to left",
+		"centerRight":  "to right",
+		"bottomLeft":   "to bottom left",
+		"bottomCenter": "to bottom",
+		"bottomRight":  "to bottom right",
+	}
+	if d, ok := names[end]; ok {
+		return d
+	}
+	opposite := map[string]string{
+		"topLeft":      "to bottom right",
+		"topCenter":    "to bottom",
+		"topRight":     "to bottom left",
+		"centerLeft":   "to right",
+		"centerRight":  "to left",
+		"bottomLeft":   "to top right",
+		"bottomCenter": "to top",
+		"bottomRight":  "to top left",
+	}
+	if d, ok := opposite[begin]; ok {
+		return d
+	}
+	return "to right"
+}
+
+func shadow(sh map[string]interface{}) string {
+	var x, y, blur float64
+	if off, ok := sh["offset"].(map[string]interface{}); ok {
+		x, _ = num(off["x"])
+		y, _ = num(off["y"])
+	}
+	blur, _ = num(sh["blur_radius"])
+	c := color(sh["color"])
+	if c == "" {
+		c = "rgba(0,0,0,.25)"
+	}
+	return fmt.Sprintf("%s %s %s %s", px(x), px(y), px(blur), c)
+}
+
+func crossAxis(v string) string {
+	switch v {
+	case "start":
+		return "flex-start"
+	case "end":
+		return "flex-end"
+	case "stretch":
+		return "stretch"
+	case "center":
+		return "center"
+	}
+	return "stretch"
+}
+
+func mainAxis(v string) string {
+	switch v {
+	case "center":
+		return "center"
+	case "end":
+		return "flex-end"
+	case "space_between":
+		return "space-between"
+	case "space_around":
+		return "space-around"
+	case "space_evenly":
+		return "space-evenly"
+	}
+	return "flex-start"
+}
+
+func fontWeight(w string) string {
+	switch w {
+	case "bold":
+		return "700"
+	case "semi_bold", "semibold":
+		return "600"
+	case "medium":
+		return "500"
+	case "light":
+		return "300"
+	}
+	for _, r := range w {
+		if r < '0' || r > '9' {
+			return "400"
+		}
+	}
+	return w
+}
+
+func describeAction(v interface{}) string {
+	action, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if route := str(action, "route"); route != "" {
+		return fmt.Sprintf("%s → %s", str(action, "type"), route)
+	}
+	return str(action, "type")
+}
+
+// iconLabel abbreviates a Material icon name so it fits inside the icon box.
+func iconLabel(name string) string {
+	parts := strings.Split(name, "_")
+	label := ""
+	for _, p := range parts {
+		if p != "" {
+			label += strings.ToUpper(p[:1])
+		}
+	}
+	if len(label) > 3 {
+		label = label[:3]
+	}
+	return label
+}
+
+// color passes through hex colors (#RGB, #RRGGBB, #RRGGBBAA are all valid
+// CSS) and maps the few named values used in schemas.
+func color(v interface{}) string {
+	s, ok := v.(string)
+	if !ok {
+		return ""
+	}
+	if s == "transparent" {
+		return s
+	}
+	if strings.HasPrefix(s, "#") && isHex(s[1:]) {
+		switch len(s) {
+		case 4, 7, 9:
+			return s
+		}
+	}
+	return ""
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func css(style map[string]string) string {
+	keys := make([]string, 0, len(style))
+	for k := range style {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s:%s", k, style[k]))
+	}
+	return html.EscapeString(strings.Join(parts, ";"))
+}
+
+func str(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+func num(v interface{}) (float64, bool) {
+	n, ok := v.(float64)
+	return n, ok
+}
+
+func clamp(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func px(v float64) string {
+	return fmt.Sprintf("%gpx", v)
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/models"
+	"fmt"
+)
+
+type AssetRepository struct {
+	db *database.DB
+}
+
+func NewAssetRepository(db *database.DB) *AssetRepository {
+	return &AssetRepository{db: db}
+}
+
+const assetColumns = `id, name, kind, version, url, content_hash, size_bytes, is_active, created_at, created_by`
+
+func scanAsset(row rowScanner) (*models.Asset, error) {
+	a := &models.Asset{}
+	err := row.Scan(
+		&a.ID, &a.Name, &a.Kind, &a.Version, &a.URL, &a.ContentHash,
+		&a.SizeBytes, &a.IsActive, &a.CreatedAt, &a.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *AssetRepository) queryAssets(query string, args ...interface{}) ([]models.Asset, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assets := make([]models.Asset, 0)
+	for rows.Next() {
+		a, err := scanAsset(rows)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, *a)
+	}
+	return assets, rows.Err()
+}
+
+// GetLatest returns the newest active version of every asset, optionally
+// limited to one kind.
+func (r *AssetRepository) GetLatest(kind string) ([]models.Asset, error) {
+	return r.queryAssets(`
+        SELECT DISTINCT ON (kind, name) `+assetColumns+`
+        FROM assets
+        WHERE is_active = true AND ($1 = '' OR kind = $1)
+        ORDER BY kind, name, version DESC
+    `, kind)
+}
+
+// GetVersions returns every version of one asset, newest first.
+func (r *AssetRepository) GetVersions(kind, name string) ([]models.Asset, error) {
+	return r.queryAssets(`
+        SELECT `+assetColumns+` FROM assets
+        WHERE kind = $1 AND name = $2
+        ORDER BY version DESC
+    `, kind, name)
+}
+
+// Get returns a specific version, or the newest active one when version is 0.
+func (r *AssetRepository) Get(kind, name string, version int) (*models.Asset, error) {
+	a, err := scanAsset(r.db.QueryRow(`
+        SELECT `+assetColumns+` FROM assets
+        WHERE kind = $1 AND name = $2
+          AND (($3 = 0 AND is_active = true) OR version = $3)
+        ORDER BY version DESC
+        LIMIT 1
+    `, kind, name, version))
+	if err != nil {
+		return nil, fmt.Errorf("asset not found: %w", err)
+	}
+	return a, nil
+}
+
+// Create registers a new version of an asset, numbered after the highest
+// existing version of the same name and kind.
+func (r *AssetRepository) Create(asset *models.Asset, userID int) (*models.Asset, error) {
+	return scanAsset(r.db.QueryRow(`
+        INSERT INTO assets (name, kind, version, url, content_hash, size_bytes, created_by)
+        SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6
+        FROM assets WHERE kind = $2 AND name = $1
+        RETURNING `+assetColumns,
+		asset.Name, asset.Kind, asset.URL, asset.ContentHash, asset.SizeBytes, userID,
+	))
+}
+
+func (r *AssetRepository) SetActive(kind, name string, version int, active bool) error {
+	res, err := r.db.Exec(`
+        UPDATE assets SET is_active = $4
+        WHERE kind = $1 AND name = $2 AND version = $3
+    `, kind, name, version, active)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("asset not found")
+	}
+	return nil
+}
+
+// ActiveNames returns the set of names with at least one active version.
+func (r *AssetRepository) ActiveNames(kind string) (map[string]bool, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT name FROM assets WHERE kind = $1 AND is_active = true`, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names[name] = true
+	}
+	return names, rows.Err()
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/models"
+	"fmt"
+	"time"
+)
+
+type BlockedSubmitterRepository struct {
+	db *database.DB
+}
+
+func NewBlockedSubmitterRepository(db *database.DB) *BlockedSubmitterRepository {
+	return &BlockedSubmitterRepository{db: db}
+}
+
+const blockedSubmitterColumns = `id, kind, value, reason, automatic, expires_at, created_at, created_by`
+
+func scanBlockedSubmitter(row rowScanner) (*models.BlockedSubmitter, error) {
+	b := &models.BlockedSubmitter{}
+	if err := row.Scan(&b.ID, &b.Kind, &b.Value, &b.Reason, &b.Automatic, &b.ExpiresAt, &b.CreatedAt, &b.CreatedBy); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// GetAll lists blocked submitters, newest first. Expired entries are only
+// included when expired is true.
+func (r *BlockedSubmitterRepository) GetAll(expired bool) ([]models.BlockedSubmitter, error) {
+	query := `SELECT ` + blockedSubmitterColumns + ` FROM blocked_submitters`
+	if !expired {
+		query += ` WHERE expires_at IS NULL OR expires_at > NOW()`
+	}
+	rows, err := r.db.Query(query + ` ORDER BY id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocked := make([]models.BlockedSubmitter, 0)
+	for rows.Next() {
+		b, err := scanBlockedSubmitter(rows)
+		if err != nil {
+			return nil, err
+		}
+		blocked = append(blocked, *b)
+	}
+	return blocked, rows.Err()
+}
+
+// Block adds a submitter, or replaces the reason and expiry of an existing
+// entry for it.
+func (r *BlockedSubmitterRepository) Block(kind, value, reason string, automatic bool, expiresAt *time.Time, userID *int) (*models.BlockedSubmitter, error) {
+	return scanBlockedSubmitter(r.db.QueryRow(`
+        INSERT INTO blocked_submitters (kind, value, reason, automatic, expires_at, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (kind, value) DO UPDATE
+        SET reason = EXCLUDED.reason, automatic = EXCLUDED.automatic, expires_at = EXCLUDED.expires_at,
+            created_at = NOW(), created_by = EXCLUDED.created_by
+        RETURNING `+blockedSubmitterColumns,
+		kind, value, reason, automatic, expiresAt, userID,
+	))
+}
+
+func (r *BlockedSubmitterRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM blocked_submitters WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("blocked submitter not found")
+	}
+	return nil
+}
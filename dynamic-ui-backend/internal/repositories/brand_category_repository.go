@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/models"
+	"fmt"
+)
+
+// BrandCategoryRepository stores which brands are listed under which
+// categories.
+type BrandCategoryRepository struct {
+	db *database.DB
+}
+
+func NewBrandCategoryRepository(db *database.DB) *BrandCategoryRepository {
+	return &BrandCategoryRepository{db: db}
+}
+
+const brandCategoryColumns = `brand_id, category_id, brand_order, category_order, created_at, created_by`
+
+func scanBrandCategory(row rowScanner) (*models.BrandCategory, error) {
+	bc := &models.BrandCategory{}
+	if err := row.Scan(&bc.BrandID, &bc.CategoryID, &bc.BrandOrder, &bc.CategoryOrder, &bc.CreatedAt, &bc.CreatedBy); err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+// Attach links a brand to a category, or updates the orders of an existing
+// link. A new link without orders goes to the end of both lists.
+func (r *BrandCategoryRepository) Attach(brandID, categoryID int, req *models.AttachBrandCategoryRequest, userID int) (*models.BrandCategory, error) {
+	return scanBrandCategory(r.db.QueryRow(`
+        INSERT INTO brand_categories (brand_id, category_id, brand_order, category_order, created_by)
+        VALUES ($1, $2,
+            COALESCE($3, (SELECT COALESCE(MAX(brand_order) + 1, 0) FROM brand_categories WHERE category_id = $2)),
+            COALESCE($4, (SELECT COALESCE(MAX(category_order) + 1, 0) FROM brand_categories WHERE brand_id = $1)),
+            $5)
+        ON CONFLICT (brand_id, category_id) DO UPDATE
+        SET brand_order = COALESCE($3, brand_categories.brand_order),
+            category_order = COALESCE($4, brand_categories.category_order)
+        RETURNING `+brandCategoryColumns,
+		brandID, categoryID, req.BrandOrder, req.CategoryOrder, userID,
+	))
+}
+
+// Detach removes the link between a brand and a category.
+func (r *BrandCategoryRepository) Detach(brandID, categoryID int) error {
+	result, err := r.db.Exec(`DELETE FROM brand_categories WHERE brand_id = $1 AND category_id = $2`, brandID, categoryID)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("brand %d is not listed under category %d", brandID, categoryID)
+	}
+	return nil
+}
+
+// GetBrands returns the active brands listed under a category, in the
+// category's order.
+func (r *BrandCategoryRepository) GetBrands(categoryID int) ([]models.Brand, error) {
+	rows, err := r.db.Query(`
+        SELECT `+brandColumns+`
+        FROM brands
+        JOIN (SELECT brand_id, brand_order FROM brand_categories WHERE category_id = $1) bc ON bc.brand_id = id
+        WHERE is_active = true AND deleted_at IS NULL
+        ORDER BY brand_order ASC, display_order ASC, id ASC
+    `, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	brands := make([]models.Brand, 0)
+	for rows.Next() {
+		brand, err := scanBrand(rows)
+		if err != nil {
+			return nil, err
+		}
+		brands = append(brands, *brand)
+	}
+	return brands, rows.Err()
+}
+
+// GetCategories returns the active categories a brand is listed under, in
+// the brand's order.
+func (r *BrandCategoryRepository) GetCategories(brandID int) ([]models.Category, error) {
+	rows, err := r.db.Query(`
+        SELECT `+categoryColumns+`
+        FROM categories
+        JOIN (SELECT category_id, category_order FROM brand_categories WHERE brand_id = $1) bc ON bc.category_id = id
+        WHERE is_active = true AND deleted_at IS NULL
+        ORDER BY category_order ASC, display_order ASC, id ASC
+    `, brandID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make([]models.Category, 0)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, *category)
+	}
+	return categories, rows.Err()
+}
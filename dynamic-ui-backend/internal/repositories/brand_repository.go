@@ -1,9 +1,11 @@
 package repositories
 
 import (
+	"database/sql"
 	"dynamic-ui-backend/internal/database"
 	"dynamic-ui-backend/internal/models"
 	"fmt"
+	"time"
 )
 
 type BrandRepository struct {
@@ -14,12 +16,27 @@ func NewBrandRepository(db *database.DB) *BrandRepository {
 	return &BrandRepository{db: db}
 }
 
+const brandColumns = `id, name, search_text, image_url, display_order, is_active,
+               created_at, updated_at, created_by, updated_by, deleted_at, deleted_by`
+
+func scanBrand(row rowScanner) (*models.Brand, error) {
+	brand := &models.Brand{}
+	err := row.Scan(
+		&brand.ID, &brand.Name, &brand.SearchText, &brand.ImageURL,
+		&brand.DisplayOrder, &brand.IsActive, &brand.CreatedAt, &brand.UpdatedAt,
+		&brand.CreatedBy, &brand.UpdatedBy, &brand.DeletedAt, &brand.DeletedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return brand, nil
+}
+
 func (r *BrandRepository) GetAll() ([]models.Brand, error) {
 	rows, err := r.db.Query(`
-        SELECT id, name, search_text, image_url, display_order, is_active,
-               created_at, updated_at, created_by, updated_by
+        SELECT ` + brandColumns + `
         FROM brands
-        WHERE is_active = true
+        WHERE is_active = true AND deleted_at IS NULL
         ORDER BY display_order ASC, id ASC
     `)
 	if err != nil {
@@ -29,31 +46,18 @@ func (r *BrandRepository) GetAll() ([]models.Brand, error) {
 
 	brands := make([]models.Brand, 0)
 	for rows.Next() {
-		var brand models.Brand
-		err := rows.Scan(
-			&brand.ID, &brand.Name, &brand.SearchText, &brand.ImageURL,
-			&brand.DisplayOrder, &brand.IsActive, &brand.CreatedAt, &brand.UpdatedAt,
-			&brand.CreatedBy, &brand.UpdatedBy,
-		)
+		brand, err := scanBrand(rows)
 		if err != nil {
 			return nil, err
 		}
-		brands = append(brands, brand)
+		brands = append(brands, *brand)
 	}
-	return brands, nil
+	return brands, rows.Err()
 }
 
+// GetByID returns a brand that is not in the trash.
 func (r *BrandRepository) GetByID(id int) (*models.Brand, error) {
-	brand := &models.Brand{}
-	err := r.db.QueryRow(`
-        SELECT id, name, search_text, image_url, display_order, is_active,
-               created_at, updated_at, created_by, updated_by
-        FROM brands WHERE id = $1
-    `, id).Scan(
-		&brand.ID, &brand.Name, &brand.SearchText, &brand.ImageURL,
-		&brand.DisplayOrder, &brand.IsActive, &brand.CreatedAt, &brand.UpdatedAt,
-		&brand.CreatedBy, &brand.UpdatedBy,
-	)
+	brand, err := scanBrand(r.db.QueryRow(`SELECT `+brandColumns+` FROM brands WHERE id = $1 AND deleted_at IS NULL`, id))
 	if err != nil {
 		return nil, fmt.Errorf("brand not found: %w", err)
 	}
@@ -107,7 +111,7 @@ func (r *BrandRepository) Update(id int, req *models.UpdateBrandRequest, userID
 		argPos++
 	}
 
-	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, name, search_text, image_url, display_order, is_active, created_at, updated_at", argPos)
+	query += fmt.Sprintf(" WHERE id = $%d AND deleted_at IS NULL RETURNING id, name, search_text, image_url, display_order, is_active, created_at, updated_at", argPos)
 	args = append(args, id)
 
 	brand := &models.Brand{}
@@ -121,7 +125,116 @@ func (r *BrandRepository) Update(id int, req *models.UpdateBrandRequest, userID
 	return brand, nil
 }
 
-func (r *BrandRepository) Delete(id int) error {
-	_, err := r.db.Exec(`DELETE FROM brands WHERE id = $1`, id)
-	return err
+// Delete moves a brand to the trash.
+func (r *BrandRepository) Delete(id, userID int) error {
+	result, err := r.db.Exec(`
+        UPDATE brands SET deleted_at = NOW(), deleted_by = $2
+        WHERE id = $1 AND deleted_at IS NULL
+    `, id, userID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("brand not found: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+// GetDeleted lists the brands in the trash, most recently deleted first.
+func (r *BrandRepository) GetDeleted() ([]models.Brand, error) {
+	rows, err := r.db.Query(`
+        SELECT ` + brandColumns + `
+        FROM brands
+        WHERE deleted_at IS NOT NULL
+        ORDER BY deleted_at DESC, id DESC
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	brands := make([]models.Brand, 0)
+	for rows.Next() {
+		brand, err := scanBrand(rows)
+		if err != nil {
+			return nil, err
+		}
+		brands = append(brands, *brand)
+	}
+	return brands, rows.Err()
+}
+
+// Restore takes a brand out of the trash.
+func (r *BrandRepository) Restore(id, userID int) (*models.Brand, error) {
+	brand, err := scanBrand(r.db.QueryRow(`
+        UPDATE brands
+        SET deleted_at = NULL, deleted_by = NULL, updated_by = $2, updated_at = NOW()
+        WHERE id = $1 AND deleted_at IS NOT NULL
+        RETURNING `+brandColumns,
+		id, userID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("brand not in trash: %w", err)
+	}
+	return brand, nil
+}
+
+// Purge permanently removes a brand from the trash and returns its image URL.
+func (r *BrandRepository) Purge(id int) (string, error) {
+	var imageURL string
+	err := r.db.QueryRow(`DELETE FROM brands WHERE id = $1 AND deleted_at IS NOT NULL RETURNING image_url`, id).Scan(&imageURL)
+	if err != nil {
+		return "", fmt.Errorf("brand not in trash: %w", err)
+	}
+	return imageURL, nil
+}
+
+// PurgeDeletedBefore permanently removes the brands deleted before the
+// cutoff and returns their image URLs.
+func (r *BrandRepository) PurgeDeletedBefore(cutoff time.Time) ([]string, error) {
+	rows, err := r.db.Query(`DELETE FROM brands WHERE deleted_at < $1 RETURNING image_url`, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	return scanImageURLs(rows)
+}
+
+// ImageReferences counts the brands, including those in the trash, that use
+// an image.
+func (r *BrandRepository) ImageReferences(imageURL string) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM brands WHERE image_url = $1`, imageURL).Scan(&count)
+	return count, err
+}
+
+func scanImageURLs(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+	urls := make([]string, 0)
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, err
+		}
+		urls = append(urls, url)
+	}
+	return urls, rows.Err()
+}
+
+var brandScope = orderScope{table: "brands", where: "deleted_at IS NULL"}
+
+// GetOrder returns the order of all brands.
+func (r *BrandRepository) GetOrder() (*models.Ordering, error) {
+	return getOrder(r.db, brandScope)
+}
+
+// Reorder sets the order of all brands at once.
+func (r *BrandRepository) Reorder(ids []int, fingerprint string, userID int) (*models.Ordering, error) {
+	return reorder(r.db, brandScope, ids, fingerprint, userID)
+}
+
+// List returns a filtered, sorted page of brands outside the trash.
+func (r *BrandRepository) List(q *models.ListQuery) ([]models.Brand, *models.ListMeta, error) {
+	return listContent(r.db, "brands", brandColumns, q, scanBrand, func(b *models.Brand, field string) (string, int) {
+		return contentSortValue(field, b.ID, b.DisplayOrder, b.Name, b.CreatedAt, b.UpdatedAt), b.ID
+	})
 }
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/models"
+	"fmt"
+)
+
+type CanaryRepository struct {
+	db *database.DB
+}
+
+func NewCanaryRepository(db *database.DB) *CanaryRepository {
+	return &CanaryRepository{db: db}
+}
+
+const canaryColumns = `id, version, screen, revision, document, percentage, error_threshold, error_count,
+               status, halt_reason, created_at, updated_at, created_by, updated_by`
+
+func scanCanary(row rowScanner) (*models.SchemaCanary, error) {
+	c := &models.SchemaCanary{}
+	err := row.Scan(
+		&c.ID, &c.Version, &c.Screen, &c.Revision, &c.Document, &c.Percentage, &c.ErrorThreshold, &c.ErrorCount,
+		&c.Status, &c.HaltReason, &c.CreatedAt, &c.UpdatedAt, &c.CreatedBy, &c.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *CanaryRepository) query(query string, args ...interface{}) ([]models.SchemaCanary, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	canaries := make([]models.SchemaCanary, 0)
+	for rows.Next() {
+		c, err := scanCanary(rows)
+		if err != nil {
+			return nil, err
+		}
+		canaries = append(canaries, *c)
+	}
+	return canaries, rows.Err()
+}
+
+// GetActive returns every running canary.
+func (r *CanaryRepository) GetActive() ([]models.SchemaCanary, error) {
+	return r.query(`SELECT `+canaryColumns+` FROM schema_canaries WHERE status = $1`, models.CanaryStatusActive)
+}
+
+// GetAll lists the canaries of a version, newest first.
+func (r *CanaryRepository) GetAll(version string) ([]models.SchemaCanary, error) {
+	return r.query(`SELECT `+canaryColumns+` FROM schema_canaries WHERE version = $1 ORDER BY id DESC`, version)
+}
+
+func (r *CanaryRepository) GetByID(id int) (*models.SchemaCanary, error) {
+	c, err := scanCanary(r.db.QueryRow(`SELECT `+canaryColumns+` FROM schema_canaries WHERE id = $1`, id))
+	if err != nil {
+		return nil, fmt.Errorf("canary not found: %w", err)
+	}
+	return c, nil
+}
+
+func (r *CanaryRepository) Create(c *models.SchemaCanary, userID int) (*models.SchemaCanary, error) {
+	return scanCanary(r.db.QueryRow(`
+        INSERT INTO schema_canaries (version, screen, revision, document, percentage, error_threshold, created_by, updated_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+        RETURNING `+canaryColumns,
+		c.Version, c.Screen, c.Revision, c.Document, c.Percentage, c.ErrorThreshold, userID,
+	))
+}
+
+// Update changes the rollout settings of a running canary.
+func (r *CanaryRepository) Update(id int, req *models.UpdateCanaryRequest, userID int) (*models.SchemaCanary, error) {
+	query := `UPDATE schema_canaries SET updated_by = $1, updated_at = NOW()`
+	args := []interface{}{userID}
+	argPos := 2
+
+	if req.Percentage != nil {
+		query += fmt.Sprintf(", percentage = $%d", argPos)
+		args = append(args, *req.Percentage)
+		argPos++
+	}
+	if req.ErrorThreshold != nil {
+		query += fmt.Sprintf(", error_threshold = $%d", argPos)
+		args = append(args, *req.ErrorThreshold)
+		argPos++
+	}
+
+	query += fmt.Sprintf(" WHERE id = $%d AND status = $%d RETURNING "+canaryColumns, argPos, argPos+1)
+	args = append(args, id, models.CanaryStatusActive)
+
+	c, err := scanCanary(r.db.QueryRow(query, args...))
+	if err != nil {
+		return nil, fmt.Errorf("active canary not found: %w", err)
+	}
+	return c, nil
+}
+
+// Finish moves a running canary to a final status. It fails when the canary
+// is no longer active, so concurrent promote/abort/halt cannot both win.
+func (r *CanaryRepository) Finish(id int, status, reason string, userID *int) (*models.SchemaCanary, error) {
+	c, err := scanCanary(r.db.QueryRow(`
+        UPDATE schema_canaries
+        SET status = $1, halt_reason = $2, updated_by = COALESCE($3, updated_by), updated_at = NOW()
+        WHERE id = $4 AND status = $5
+        RETURNING `+canaryColumns,
+		status, reason, userID, id, models.CanaryStatusActive,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("active canary not found: %w", err)
+	}
+	return c, nil
+}
+
+// RecordError stores a device's render error report and returns the number
+// of distinct reporters for the canary. Reports from the same IP count once
+// however many device IDs they claim; reports stored before IPs were
+// recorded count per device.
+func (r *CanaryRepository) RecordError(id int, deviceID, ip, message string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+        INSERT INTO schema_canary_errors (canary_id, device_id, ip, message)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (canary_id, device_id) DO NOTHING`,
+		id, deviceID, ip, message,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = tx.QueryRow(`
+        UPDATE schema_canaries
+        SET error_count = (
+            SELECT COUNT(DISTINCT COALESCE(NULLIF(ip, ''), device_id))
+            FROM schema_canary_errors WHERE canary_id = $1
+        )
+        WHERE id = $1
+        RETURNING error_count`,
+		id,
+	).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, tx.Commit()
+}
@@ -1,11 +1,22 @@
 package repositories
 
 import (
+	"database/sql"
 	"dynamic-ui-backend/internal/database"
 	"dynamic-ui-backend/internal/models"
+	"errors"
 	"fmt"
+	"time"
 )
 
+// ErrCategoryHasChildren is returned when deleting a category that still has
+// subcategories.
+var ErrCategoryHasChildren = errors.New("category has subcategories")
+
+// ErrParentDeleted is returned when restoring a category whose parent is
+// still in the trash.
+var ErrParentDeleted = errors.New("parent category is in the trash")
+
 type CategoryRepository struct {
 	db *database.DB
 }
@@ -14,14 +25,31 @@ func NewCategoryRepository(db *database.DB) *CategoryRepository {
 	return &CategoryRepository{db: db}
 }
 
-func (r *CategoryRepository) GetAll() ([]models.Category, error) {
+const categoryColumns = `id, parent_id, name, search_text, image_url, display_order, is_active,
+               created_at, updated_at, created_by, updated_by, deleted_at, deleted_by`
+
+func scanCategory(row rowScanner) (*models.Category, error) {
+	cat := &models.Category{}
+	err := row.Scan(
+		&cat.ID, &cat.ParentID, &cat.Name, &cat.SearchText, &cat.ImageURL,
+		&cat.DisplayOrder, &cat.IsActive, &cat.CreatedAt, &cat.UpdatedAt,
+		&cat.CreatedBy, &cat.UpdatedBy, &cat.DeletedAt, &cat.DeletedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
+// GetAll returns the active categories, siblings in display order, or
+// every category outside the trash with inactive set.
+func (r *CategoryRepository) GetAll(inactive bool) ([]models.Category, error) {
 	rows, err := r.db.Query(`
-        SELECT id, name, search_text, image_url, display_order, is_active,
-               created_at, updated_at, created_by, updated_by
+        SELECT `+categoryColumns+`
         FROM categories
-        WHERE is_active = true
+        WHERE ($1 OR is_active = true) AND deleted_at IS NULL
         ORDER BY display_order ASC, id ASC
-    `)
+    `, inactive)
 	if err != nil {
 		return nil, err
 	}
@@ -29,31 +57,18 @@ func (r *CategoryRepository) GetAll() ([]models.Category, error) {
 
 	categories := make([]models.Category, 0)
 	for rows.Next() {
-		var cat models.Category
-		err := rows.Scan(
-			&cat.ID, &cat.Name, &cat.SearchText, &cat.ImageURL,
-			&cat.DisplayOrder, &cat.IsActive, &cat.CreatedAt, &cat.UpdatedAt,
-			&cat.CreatedBy, &cat.UpdatedBy,
-		)
+		cat, err := scanCategory(rows)
 		if err != nil {
 			return nil, err
 		}
-		categories = append(categories, cat)
+		categories = append(categories, *cat)
 	}
-	return categories, nil
+	return categories, rows.Err()
 }
 
+// GetByID returns a category that is not in the trash.
 func (r *CategoryRepository) GetByID(id int) (*models.Category, error) {
-	cat := &models.Category{}
-	err := r.db.QueryRow(`
-        SELECT id, name, search_text, image_url, display_order, is_active,
-               created_at, updated_at, created_by, updated_by
-        FROM categories WHERE id = $1
-    `, id).Scan(
-		&cat.ID, &cat.Name, &cat.SearchText, &cat.ImageURL,
-		&cat.DisplayOrder, &cat.IsActive, &cat.CreatedAt, &cat.UpdatedAt,
-		&cat.CreatedBy, &cat.UpdatedBy,
-	)
+	cat, err := scanCategory(r.db.QueryRow(`SELECT `+categoryColumns+` FROM categories WHERE id = $1 AND deleted_at IS NULL`, id))
 	if err != nil {
 		return nil, fmt.Errorf("category not found: %w", err)
 	}
@@ -61,19 +76,12 @@ func (r *CategoryRepository) GetByID(id int) (*models.Category, error) {
 }
 
 func (r *CategoryRepository) Create(req *models.CreateCategoryRequest, userID int) (*models.Category, error) {
-	cat := &models.Category{}
-	err := r.db.QueryRow(`
-        INSERT INTO categories (name, search_text, image_url, display_order, created_by, updated_by)
-        VALUES ($1, $2, $3, $4, $5, $5)
-        RETURNING id, name, search_text, image_url, display_order, is_active, created_at, updated_at
-    `, req.Name, req.SearchText, req.ImageURL, req.DisplayOrder, userID).Scan(
-		&cat.ID, &cat.Name, &cat.SearchText, &cat.ImageURL,
-		&cat.DisplayOrder, &cat.IsActive, &cat.CreatedAt, &cat.UpdatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return cat, nil
+	return scanCategory(r.db.QueryRow(`
+        INSERT INTO categories (parent_id, name, search_text, image_url, display_order, created_by, updated_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $6)
+        RETURNING `+categoryColumns,
+		req.ParentID, req.Name, req.SearchText, req.ImageURL, req.DisplayOrder, userID,
+	))
 }
 
 func (r *CategoryRepository) Update(id int, req *models.UpdateCategoryRequest, userID int) (*models.Category, error) {
@@ -107,21 +115,213 @@ func (r *CategoryRepository) Update(id int, req *models.UpdateCategoryRequest, u
 		argPos++
 	}
 
-	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, name, search_text, image_url, display_order, is_active, created_at, updated_at", argPos)
+	query += fmt.Sprintf(" WHERE id = $%d AND deleted_at IS NULL RETURNING %s", argPos, categoryColumns)
 	args = append(args, id)
 
-	cat := &models.Category{}
-	err := r.db.QueryRow(query, args...).Scan(
-		&cat.ID, &cat.Name, &cat.SearchText, &cat.ImageURL,
-		&cat.DisplayOrder, &cat.IsActive, &cat.CreatedAt, &cat.UpdatedAt,
-	)
+	return scanCategory(r.db.QueryRow(query, args...))
+}
+
+// Move puts a category under a new parent, or at the root when parentID is
+// nil, optionally changing its display order among the new siblings. Moving
+// a category under itself or one of its descendants is refused. Moves are
+// serialized so two concurrent moves cannot create a cycle together.
+func (r *CategoryRepository) Move(id int, parentID *int, displayOrder *int, userID int) (*models.Category, error) {
+	tx, err := r.db.Begin()
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return nil, err
+	}
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM categories WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("category not found: %w", sql.ErrNoRows)
+	}
+
+	if parentID != nil {
+		var cycle, parentExists bool
+		err := tx.QueryRow(`
+            WITH RECURSIVE ancestors AS (
+                SELECT id, parent_id FROM categories WHERE id = $1 AND deleted_at IS NULL
+                UNION ALL
+                SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+            )
+            SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2), EXISTS (SELECT 1 FROM ancestors)
+        `, *parentID, id).Scan(&cycle, &parentExists)
+		if err != nil {
+			return nil, err
+		}
+		if !parentExists {
+			return nil, fmt.Errorf("parent category %d not found", *parentID)
+		}
+		if cycle {
+			return nil, fmt.Errorf("cannot move a category under itself or one of its subcategories")
+		}
+	}
+
+	cat, err := scanCategory(tx.QueryRow(`
+        UPDATE categories
+        SET parent_id = $1, display_order = COALESCE($2, display_order), updated_by = $3, updated_at = NOW()
+        WHERE id = $4
+        RETURNING `+categoryColumns,
+		parentID, displayOrder, userID, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 	return cat, nil
 }
 
-func (r *CategoryRepository) Delete(id int) error {
-	_, err := r.db.Exec(`DELETE FROM categories WHERE id = $1`, id)
-	return err
+// Delete moves a category to the trash. Categories that still have
+// subcategories cannot be deleted; move or delete the subcategories first.
+func (r *CategoryRepository) Delete(id, userID int) error {
+	var children int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM categories WHERE parent_id = $1 AND deleted_at IS NULL`, id).Scan(&children); err != nil {
+		return err
+	}
+	if children > 0 {
+		return fmt.Errorf("%w: %d", ErrCategoryHasChildren, children)
+	}
+	result, err := r.db.Exec(`
+        UPDATE categories SET deleted_at = NOW(), deleted_by = $2
+        WHERE id = $1 AND deleted_at IS NULL
+    `, id, userID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("category not found: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+// GetDeleted lists the categories in the trash, most recently deleted first.
+func (r *CategoryRepository) GetDeleted() ([]models.Category, error) {
+	rows, err := r.db.Query(`
+        SELECT ` + categoryColumns + `
+        FROM categories
+        WHERE deleted_at IS NOT NULL
+        ORDER BY deleted_at DESC, id DESC
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make([]models.Category, 0)
+	for rows.Next() {
+		cat, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, *cat)
+	}
+	return categories, rows.Err()
+}
+
+// Restore takes a category out of the trash. Its parent has to be restored
+// first.
+func (r *CategoryRepository) Restore(id, userID int) (*models.Category, error) {
+	var deleted, parentDeleted bool
+	err := r.db.QueryRow(`
+        SELECT c.deleted_at IS NOT NULL, COALESCE(p.deleted_at IS NOT NULL, false)
+        FROM categories c LEFT JOIN categories p ON p.id = c.parent_id
+        WHERE c.id = $1
+    `, id).Scan(&deleted, &parentDeleted)
+	if err == sql.ErrNoRows || (err == nil && !deleted) {
+		return nil, fmt.Errorf("category not in trash: %w", sql.ErrNoRows)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if parentDeleted {
+		return nil, ErrParentDeleted
+	}
+
+	return scanCategory(r.db.QueryRow(`
+        UPDATE categories
+        SET deleted_at = NULL, deleted_by = NULL, updated_by = $2, updated_at = NOW()
+        WHERE id = $1 AND deleted_at IS NOT NULL
+        RETURNING `+categoryColumns,
+		id, userID,
+	))
+}
+
+// Purge permanently removes a category from the trash and returns its image
+// URL. Subcategories still in the trash have to be purged first.
+func (r *CategoryRepository) Purge(id int) (string, error) {
+	var children int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM categories WHERE parent_id = $1`, id).Scan(&children); err != nil {
+		return "", err
+	}
+	if children > 0 {
+		return "", fmt.Errorf("%w: %d", ErrCategoryHasChildren, children)
+	}
+	var imageURL string
+	err := r.db.QueryRow(`DELETE FROM categories WHERE id = $1 AND deleted_at IS NOT NULL RETURNING image_url`, id).Scan(&imageURL)
+	if err != nil {
+		return "", fmt.Errorf("category not in trash: %w", err)
+	}
+	return imageURL, nil
+}
+
+// PurgeDeletedBefore permanently removes the categories deleted before the
+// cutoff and returns their image URLs. A category is kept while any of its
+// subcategories is live or was deleted after the cutoff.
+func (r *CategoryRepository) PurgeDeletedBefore(cutoff time.Time) ([]string, error) {
+	rows, err := r.db.Query(`
+        DELETE FROM categories c
+        WHERE c.deleted_at < $1
+          AND NOT EXISTS (
+              SELECT 1 FROM categories child
+              WHERE child.parent_id = c.id AND (child.deleted_at IS NULL OR child.deleted_at >= $1)
+          )
+        RETURNING c.image_url
+    `, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	return scanImageURLs(rows)
+}
+
+// ImageReferences counts the categories, including those in the trash, that
+// use an image.
+func (r *CategoryRepository) ImageReferences(imageURL string) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM categories WHERE image_url = $1`, imageURL).Scan(&count)
+	return count, err
+}
+
+// siblingScope orders the children of a parent, or the top-level categories
+// when parentID is nil.
+func siblingScope(parentID *int) orderScope {
+	if parentID == nil {
+		return orderScope{table: "categories", where: "parent_id IS NULL AND deleted_at IS NULL"}
+	}
+	return orderScope{table: "categories", where: "parent_id = $1 AND deleted_at IS NULL", args: []interface{}{*parentID}}
+}
+
+// GetOrder returns the order of the children of a parent.
+func (r *CategoryRepository) GetOrder(parentID *int) (*models.Ordering, error) {
+	return getOrder(r.db, siblingScope(parentID))
+}
+
+// Reorder sets the order of all children of a parent at once.
+func (r *CategoryRepository) Reorder(parentID *int, ids []int, fingerprint string, userID int) (*models.Ordering, error) {
+	return reorder(r.db, siblingScope(parentID), ids, fingerprint, userID)
+}
+
+// List returns a filtered, sorted page of categories outside the trash.
+func (r *CategoryRepository) List(q *models.ListQuery) ([]models.Category, *models.ListMeta, error) {
+	return listContent(r.db, "categories", categoryColumns, q, scanCategory, func(c *models.Category, field string) (string, int) {
+		return contentSortValue(field, c.ID, c.DisplayOrder, c.Name, c.CreatedAt, c.UpdatedAt), c.ID
+	})
 }
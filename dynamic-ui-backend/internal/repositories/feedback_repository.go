@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/models"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+type FeedbackRepository struct {
+	db *database.DB
+}
+
+func NewFeedbackRepository(db *database.DB) *FeedbackRepository {
+	return &FeedbackRepository{db: db}
+}
+
+const feedbackColumns = `id, screen, screen_id, version, schema_version, revision, answers,
+               device_id, platform, app_version, user_id, created_at`
+
+func scanFeedback(row rowScanner) (*models.FeedbackResponse, error) {
+	f := &models.FeedbackResponse{}
+	var answers []byte
+	err := row.Scan(
+		&f.ID, &f.Screen, &f.ScreenID, &f.Version, &f.SchemaVersion, &f.Revision, &answers,
+		&f.DeviceID, &f.Platform, &f.AppVersion, &f.UserID, &f.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	f.Answers = answers
+	return f, nil
+}
+
+func (r *FeedbackRepository) Create(f *models.FeedbackResponse) (*models.FeedbackResponse, error) {
+	return scanFeedback(r.db.QueryRow(`
+        INSERT INTO feedback_responses (screen, screen_id, version, schema_version, revision, answers,
+                                        device_id, platform, app_version, user_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING `+feedbackColumns,
+		f.Screen, f.ScreenID, f.Version, f.SchemaVersion, f.Revision, []byte(f.Answers),
+		f.DeviceID, f.Platform, f.AppVersion, f.UserID,
+	))
+}
+
+// feedbackWhere builds the WHERE clause for a filter, with arguments from $1.
+func feedbackWhere(f *models.FeedbackFilter) (string, []interface{}) {
+	clause := ` WHERE f.screen = $1 AND f.version = $2`
+	args := []interface{}{f.Screen, f.Version}
+	argPos := 3
+
+	if f.From != nil {
+		clause += fmt.Sprintf(" AND f.created_at >= $%d", argPos)
+		args = append(args, *f.From)
+		argPos++
+	}
+	if f.To != nil {
+		clause += fmt.Sprintf(" AND f.created_at < $%d", argPos)
+		args = append(args, *f.To)
+		argPos++
+	}
+	if f.AppVersion != "" {
+		clause += fmt.Sprintf(" AND f.app_version = $%d", argPos)
+		args = append(args, f.AppVersion)
+		argPos++
+	}
+	if f.Platform != "" {
+		clause += fmt.Sprintf(" AND f.platform = $%d", argPos)
+		args = append(args, f.Platform)
+	}
+	return clause, args
+}
+
+// CountOverTime counts matching responses per period. Interval is a
+// date_trunc unit ("hour", "day", "week" or "month") and must be checked by
+// the caller.
+func (r *FeedbackRepository) CountOverTime(f *models.FeedbackFilter, interval string) ([]models.FeedbackBucket, error) {
+	where, args := feedbackWhere(f)
+	rows, err := r.db.Query(`
+        SELECT date_trunc('`+interval+`', f.created_at) AS period, COUNT(*)
+        FROM feedback_responses f`+where+`
+        GROUP BY period ORDER BY period`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := make([]models.FeedbackBucket, 0)
+	for rows.Next() {
+		var b models.FeedbackBucket
+		if err := rows.Scan(&b.Period, &b.Count); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, rows.Err()
+}
+
+// AnsweredCounts returns how many matching responses answered each key.
+func (r *FeedbackRepository) AnsweredCounts(f *models.FeedbackFilter) (map[string]int, error) {
+	where, args := feedbackWhere(f)
+	rows, err := r.db.Query(`
+        SELECT a.key, COUNT(*)
+        FROM feedback_responses f
+        CROSS JOIN LATERAL jsonb_each(f.answers) a`+where+`
+        GROUP BY a.key`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+		counts[key] = count
+	}
+	return counts, rows.Err()
+}
+
+// ValueCounts counts the values given for the keys. String answers count as
+// one value; list answers count each element.
+func (r *FeedbackRepository) ValueCounts(f *models.FeedbackFilter, keys []string) ([]models.FeedbackValueCount, error) {
+	return r.valueCounts(f, keys, `
+        SELECT a.key, e.value, COUNT(*)
+        FROM feedback_responses f
+        CROSS JOIN LATERAL jsonb_each(f.answers) a
+        CROSS JOIN LATERAL jsonb_array_elements_text(
+            CASE WHEN jsonb_typeof(a.value) = 'array' THEN a.value ELSE jsonb_build_array(a.value) END
+        ) e`, `jsonb_typeof(a.value) IN ('string', 'array')`)
+}
+
+// NumberCounts counts the numeric values given for the keys.
+func (r *FeedbackRepository) NumberCounts(f *models.FeedbackFilter, keys []string) ([]models.FeedbackValueCount, error) {
+	return r.valueCounts(f, keys, `
+        SELECT a.key, a.value #>> '{}', COUNT(*)
+        FROM feedback_responses f
+        CROSS JOIN LATERAL jsonb_each(f.answers) a`, `jsonb_typeof(a.value) = 'number'`)
+}
+
+func (r *FeedbackRepository) valueCounts(f *models.FeedbackFilter, keys []string, from, condition string) ([]models.FeedbackValueCount, error) {
+	counts := make([]models.FeedbackValueCount, 0)
+	if len(keys) == 0 {
+		return counts, nil
+	}
+	where, args := feedbackWhere(f)
+	args = append(args, pq.Array(keys))
+	rows, err := r.db.Query(from+where+fmt.Sprintf(` AND a.key = ANY($%d) AND `, len(args))+condition+`
+        GROUP BY 1, 2`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c models.FeedbackValueCount
+		if err := rows.Scan(&c.Key, &c.Value, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// AnswerKeys lists the state keys answered in any matching response.
+func (r *FeedbackRepository) AnswerKeys(f *models.FeedbackFilter) ([]string, error) {
+	where, args := feedbackWhere(f)
+	rows, err := r.db.Query(`SELECT DISTINCT jsonb_object_keys(f.answers) FROM feedback_responses f`+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]string, 0)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// Each calls fn for every matching response, oldest first, reading rows as
+// they arrive instead of loading them all.
+func (r *FeedbackRepository) Each(f *models.FeedbackFilter, fn func(*models.FeedbackResponse) error) error {
+	where, args := feedbackWhere(f)
+	rows, err := r.db.Query(`SELECT `+feedbackColumns+` FROM feedback_responses f`+where+` ORDER BY f.id`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		response, err := scanFeedback(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(response); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/models"
+	"encoding/json"
+	"fmt"
+)
+
+type FlagRepository struct {
+	db *database.DB
+}
+
+func NewFlagRepository(db *database.DB) *FlagRepository {
+	return &FlagRepository{db: db}
+}
+
+const flagColumns = `id, key, description, value_type, default_value, rules, is_active,
+               created_at, updated_at, created_by, updated_by`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanFlag(row rowScanner) (*models.FeatureFlag, error) {
+	flag := &models.FeatureFlag{}
+	var defaultValue, rules []byte
+	err := row.Scan(
+		&flag.ID, &flag.Key, &flag.Description, &flag.ValueType, &defaultValue, &rules,
+		&flag.IsActive, &flag.CreatedAt, &flag.UpdatedAt, &flag.CreatedBy, &flag.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	flag.DefaultValue = json.RawMessage(defaultValue)
+	if err := json.Unmarshal(rules, &flag.Rules); err != nil {
+		return nil, fmt.Errorf("invalid rules for flag %s: %w", flag.Key, err)
+	}
+	return flag, nil
+}
+
+func (r *FlagRepository) GetAll() ([]models.FeatureFlag, error) {
+	rows, err := r.db.Query(`SELECT ` + flagColumns + ` FROM feature_flags ORDER BY key ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	flags := make([]models.FeatureFlag, 0)
+	for rows.Next() {
+		flag, err := scanFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, *flag)
+	}
+	return flags, rows.Err()
+}
+
+func (r *FlagRepository) GetByKey(key string) (*models.FeatureFlag, error) {
+	flag, err := scanFlag(r.db.QueryRow(`SELECT `+flagColumns+` FROM feature_flags WHERE key = $1`, key))
+	if err != nil {
+		return nil, fmt.Errorf("flag not found: %w", err)
+	}
+	return flag, nil
+}
+
+func (r *FlagRepository) Create(req *models.CreateFlagRequest, userID int) (*models.FeatureFlag, error) {
+	rules, err := json.Marshal(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+	return scanFlag(r.db.QueryRow(`
+        INSERT INTO feature_flags (key, description, value_type, default_value, rules, created_by, updated_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $6)
+        RETURNING `+flagColumns,
+		req.Key, req.Description, req.ValueType, []byte(req.DefaultValue), rules, userID,
+	))
+}
+
+func (r *FlagRepository) Update(key string, req *models.UpdateFlagRequest, userID int) (*models.FeatureFlag, error) {
+	query := `UPDATE feature_flags SET updated_by = $1, updated_at = NOW()`
+	args := []interface{}{userID}
+	argPos := 2
+
+	if req.Description != nil {
+		query += fmt.Sprintf(", description = $%d", argPos)
+		args = append(args, *req.Description)
+		argPos++
+	}
+	if req.DefaultValue != nil {
+		query += fmt.Sprintf(", default_value = $%d", argPos)
+		args = append(args, []byte(req.DefaultValue))
+		argPos++
+	}
+	if req.Rules != nil {
+		rules, err := json.Marshal(*req.Rules)
+		if err != nil {
+			return nil, err
+		}
+		query += fmt.Sprintf(", rules = $%d", argPos)
+		args = append(args, rules)
+		argPos++
+	}
+	if req.IsActive != nil {
+		query += fmt.Sprintf(", is_active = $%d", argPos)
+		args = append(args, *req.IsActive)
+		argPos++
+	}
+
+	query += fmt.Sprintf(" WHERE key = $%d RETURNING "+flagColumns, argPos)
+	args = append(args, key)
+
+	return scanFlag(r.db.QueryRow(query, args...))
+}
+
+func (r *FlagRepository) Delete(key string) error {
+	result, err := r.db.Exec(`DELETE FROM feature_flags WHERE key = $1`, key)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("flag not found")
+	}
+	return nil
+}
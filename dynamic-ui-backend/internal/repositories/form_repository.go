@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/models"
+)
+
+type FormRepository struct {
+	db *database.DB
+}
+
+func NewFormRepository(db *database.DB) *FormRepository {
+	return &FormRepository{db: db}
+}
+
+const formSubmissionColumns = `id, form_id, screen, screen_id, version, schema_version, revision, data,
+               device_id, platform, app_version, user_id, created_at`
+
+func scanFormSubmission(row rowScanner) (*models.FormSubmission, error) {
+	s := &models.FormSubmission{}
+	var data []byte
+	err := row.Scan(
+		&s.ID, &s.FormID, &s.Screen, &s.ScreenID, &s.Version, &s.SchemaVersion, &s.Revision, &data,
+		&s.DeviceID, &s.Platform, &s.AppVersion, &s.UserID, &s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.Data = data
+	return s, nil
+}
+
+func (r *FormRepository) Create(s *models.FormSubmission) (*models.FormSubmission, error) {
+	return scanFormSubmission(r.db.QueryRow(`
+        INSERT INTO form_submissions (form_id, screen, screen_id, version, schema_version, revision, data,
+                                      device_id, platform, app_version, user_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        RETURNING `+formSubmissionColumns,
+		s.FormID, s.Screen, s.ScreenID, s.Version, s.SchemaVersion, s.Revision, []byte(s.Data),
+		s.DeviceID, s.Platform, s.AppVersion, s.UserID,
+	))
+}
+
+// GetByForm returns the latest submissions of a form, newest first.
+func (r *FormRepository) GetByForm(formID string, limit int) ([]models.FormSubmission, error) {
+	rows, err := r.db.Query(`SELECT `+formSubmissionColumns+` FROM form_submissions
+        WHERE form_id = $1 ORDER BY id DESC LIMIT $2`, formID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	submissions := make([]models.FormSubmission, 0)
+	for rows.Next() {
+		s, err := scanFormSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, *s)
+	}
+	return submissions, rows.Err()
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/models"
+	"fmt"
+)
+
+type KillSwitchRepository struct {
+	db *database.DB
+}
+
+func NewKillSwitchRepository(db *database.DB) *KillSwitchRepository {
+	return &KillSwitchRepository{db: db}
+}
+
+const killSwitchColumns = `id, widget_type, widget_id, platform, min_app_version, max_app_version, reason,
+               is_active, created_at, updated_at, created_by, updated_by`
+
+func scanKillSwitch(row rowScanner) (*models.KillSwitch, error) {
+	ks := &models.KillSwitch{}
+	err := row.Scan(
+		&ks.ID, &ks.WidgetType, &ks.WidgetID, &ks.Platform, &ks.MinAppVersion, &ks.MaxAppVersion, &ks.Reason,
+		&ks.IsActive, &ks.CreatedAt, &ks.UpdatedAt, &ks.CreatedBy, &ks.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+func (r *KillSwitchRepository) GetAll() ([]models.KillSwitch, error) {
+	rows, err := r.db.Query(`SELECT ` + killSwitchColumns + ` FROM widget_kill_switches ORDER BY id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	switches := make([]models.KillSwitch, 0)
+	for rows.Next() {
+		ks, err := scanKillSwitch(rows)
+		if err != nil {
+			return nil, err
+		}
+		switches = append(switches, *ks)
+	}
+	return switches, rows.Err()
+}
+
+func (r *KillSwitchRepository) GetByID(id int) (*models.KillSwitch, error) {
+	ks, err := scanKillSwitch(r.db.QueryRow(`SELECT `+killSwitchColumns+` FROM widget_kill_switches WHERE id = $1`, id))
+	if err != nil {
+		return nil, fmt.Errorf("kill switch not found: %w", err)
+	}
+	return ks, nil
+}
+
+func (r *KillSwitchRepository) Create(req *models.CreateKillSwitchRequest, userID int) (*models.KillSwitch, error) {
+	return scanKillSwitch(r.db.QueryRow(`
+        INSERT INTO widget_kill_switches (widget_type, widget_id, platform, min_app_version, max_app_version, reason, created_by, updated_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+        RETURNING `+killSwitchColumns,
+		req.WidgetType, req.WidgetID, req.Platform, req.MinAppVersion, req.MaxAppVersion, req.Reason, userID,
+	))
+}
+
+func (r *KillSwitchRepository) Update(id int, req *models.UpdateKillSwitchRequest, userID int) (*models.KillSwitch, error) {
+	query := `UPDATE widget_kill_switches SET updated_by = $1, updated_at = NOW()`
+	args := []interface{}{userID}
+	argPos := 2
+
+	if req.Platform != nil {
+		query += fmt.Sprintf(", platform = $%d", argPos)
+		args = append(args, *req.Platform)
+		argPos++
+	}
+	if req.MinAppVersion != nil {
+		query += fmt.Sprintf(", min_app_version = $%d", argPos)
+		args = append(args, *req.MinAppVersion)
+		argPos++
+	}
+	if req.MaxAppVersion != nil {
+		query += fmt.Sprintf(", max_app_version = $%d", argPos)
+		args = append(args, *req.MaxAppVersion)
+		argPos++
+	}
+	if req.Reason != nil {
+		query += fmt.Sprintf(", reason = $%d", argPos)
+		args = append(args, *req.Reason)
+		argPos++
+	}
+	if req.IsActive != nil {
+		query += fmt.Sprintf(", is_active = $%d", argPos)
+		args = append(args, *req.IsActive)
+		argPos++
+	}
+
+	query += fmt.Sprintf(" WHERE id = $%d RETURNING "+killSwitchColumns, argPos)
+	args = append(args, id)
+
+	return scanKillSwitch(r.db.QueryRow(query, args...))
+}
+
+func (r *KillSwitchRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM widget_kill_switches WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("kill switch not found")
+	}
+	return nil
+}
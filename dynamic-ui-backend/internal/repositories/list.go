@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalidListQuery is returned for an unknown sort field or a cursor that
+// is malformed or does not belong to the requested sort.
+var ErrInvalidListQuery = errors.New("invalid list query")
+
+// DefaultListSort is the order content lists use unless asked otherwise.
+const DefaultListSort = "display_order"
+
+// listSortCasts maps the sortable columns of categories and brands to the
+// type their cursor value is cast to.
+var listSortCasts = map[string]string{
+	"display_order": "int",
+	"name":          "text",
+	"created_at":    "timestamp",
+	"updated_at":    "timestamp",
+	"id":            "int",
+}
+
+const cursorTimeLayout = "2006-01-02T15:04:05.999999"
+
+// listCursor points after a row: its sort value and its id, which breaks
+// ties.
+type listCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+func sortName(q *models.ListQuery) string {
+	if q.Desc {
+		return "-" + q.Sort
+	}
+	return q.Sort
+}
+
+func encodeCursor(c listCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (*listCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c listCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// checkCursor parses a cursor's values for their column types, so a
+// tampered cursor is rejected here instead of failing the cast in SQL.
+func checkCursor(c *listCursor, cast string) error {
+	if c.ID < 0 || c.ID > math.MaxInt32 {
+		return fmt.Errorf("invalid id %d", c.ID)
+	}
+	var err error
+	switch cast {
+	case "int":
+		_, err = strconv.ParseInt(c.Value, 10, 32)
+	case "timestamp":
+		_, err = time.Parse(cursorTimeLayout, c.Value)
+	default:
+		if !utf8.ValidString(c.Value) || strings.ContainsRune(c.Value, 0) {
+			err = fmt.Errorf("invalid text value")
+		}
+	}
+	return err
+}
+
+// listWhere builds the filter of a content list. Entries in the trash are
+// never listed.
+func listWhere(q *models.ListQuery) (string, []interface{}) {
+	where := "deleted_at IS NULL"
+	args := []interface{}{}
+	argPos := 1
+
+	add := func(clause string, arg interface{}) {
+		where += fmt.Sprintf(" AND "+clause, argPos)
+		args = append(args, arg)
+		argPos++
+	}
+	if q.Name != "" {
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(q.Name)
+		add("name ILIKE '%%' || $%d || '%%'", escaped)
+	}
+	if q.IsActive != nil {
+		add("is_active = $%d", *q.IsActive)
+	}
+	if q.CreatedFrom != nil {
+		add("created_at >= $%d", *q.CreatedFrom)
+	}
+	if q.CreatedTo != nil {
+		add("created_at < $%d", *q.CreatedTo)
+	}
+	if q.UpdatedFrom != nil {
+		add("updated_at >= $%d", *q.UpdatedFrom)
+	}
+	if q.UpdatedTo != nil {
+		add("updated_at < $%d", *q.UpdatedTo)
+	}
+	if q.CreatedBy != nil {
+		add("created_by = $%d", *q.CreatedBy)
+	}
+	return where, args
+}
+
+// listContent runs a ListQuery against the categories or brands table.
+// sortValue returns the value of the sort column of a row and its id, for
+// the next page's cursor.
+func listContent[T any](db *database.DB, table, columns string, q *models.ListQuery, scan func(rowScanner) (*T, error), sortValue func(*T, string) (string, int)) ([]T, *models.ListMeta, error) {
+	if q.Sort == "" {
+		q.Sort = DefaultListSort
+	}
+	cast, ok := listSortCasts[q.Sort]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: cannot sort by %q", ErrInvalidListQuery, q.Sort)
+	}
+
+	where, args := listWhere(q)
+	meta := &models.ListMeta{Limit: q.Limit, Offset: q.Offset, Sort: sortName(q)}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM `+table+` WHERE `+where, args...).Scan(&meta.Total); err != nil {
+		return nil, nil, err
+	}
+
+	direction, compare := "ASC", ">"
+	if q.Desc {
+		direction, compare = "DESC", "<"
+	}
+	if q.Cursor != "" {
+		c, err := decodeCursor(q.Cursor)
+		if err != nil || c.Sort != meta.Sort {
+			return nil, nil, fmt.Errorf("%w: cursor does not match sort %q", ErrInvalidListQuery, meta.Sort)
+		}
+		if err := checkCursor(c, cast); err != nil {
+			return nil, nil, fmt.Errorf("%w: malformed cursor", ErrInvalidListQuery)
+		}
+		where += fmt.Sprintf(" AND (%s, id) %s ($%d::%s, $%d)", q.Sort, compare, len(args)+1, cast, len(args)+2)
+		args = append(args, c.Value, c.ID)
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE %s ORDER BY %s %s, id %s`, columns, table, where, q.Sort, direction, direction)
+	if q.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", q.Limit+1)
+	}
+	if q.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", q.Offset)
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	items := make([]T, 0)
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, *item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if q.Limit > 0 && len(items) > q.Limit {
+		items = items[:q.Limit]
+		value, id := sortValue(&items[len(items)-1], q.Sort)
+		meta.NextCursor = encodeCursor(listCursor{Sort: meta.Sort, Value: value, ID: id})
+	}
+	meta.Count = len(items)
+	return items, meta, nil
+}
+
+// contentSortValue formats the sortable fields shared by categories and
+// brands as cursor values.
+func contentSortValue(field string, id, displayOrder int, name string, createdAt, updatedAt time.Time) string {
+	switch field {
+	case "name":
+		return name
+	case "created_at":
+		return createdAt.Format(cursorTimeLayout)
+	case "updated_at":
+		return updatedAt.Format(cursorTimeLayout)
+	case "id":
+		return strconv.Itoa(id)
+	}
+	return strconv.Itoa(displayOrder)
+}
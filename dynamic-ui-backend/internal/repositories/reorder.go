@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"dynamic-ui-backend/internal/database"
+	"dynamic-ui-backend/internal/models"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrOrderChanged is returned when a reorder is based on a fingerprint
+	// that no longer matches the stored order.
+	ErrOrderChanged = errors.New("order was changed since it was read")
+	// ErrInvalidOrder is returned when the submitted ids are not exactly the
+	// ids being reordered.
+	ErrInvalidOrder = errors.New("invalid order")
+)
+
+// orderScope selects the rows ordered together by display_order: a table,
+// optionally narrowed by a condition whose arguments start at $1.
+type orderScope struct {
+	table string
+	where string
+	args  []interface{}
+}
+
+type orderedRow struct {
+	id, order int
+}
+
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (s orderScope) read(q queryer, forUpdate bool) ([]orderedRow, error) {
+	query := `SELECT id, display_order FROM ` + s.table
+	if s.where != "" {
+		query += ` WHERE ` + s.where
+	}
+	query += ` ORDER BY display_order ASC, id ASC`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+	rows, err := q.Query(query, s.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ordered := make([]orderedRow, 0)
+	for rows.Next() {
+		var row orderedRow
+		if err := rows.Scan(&row.id, &row.order); err != nil {
+			return nil, err
+		}
+		ordered = append(ordered, row)
+	}
+	return ordered, rows.Err()
+}
+
+// getOrder returns the current order of a scope with its fingerprint.
+func getOrder(db *database.DB, scope orderScope) (*models.Ordering, error) {
+	rows, err := scope.read(db, false)
+	if err != nil {
+		return nil, err
+	}
+	return newOrdering(rows), nil
+}
+
+// reorder sets display_order of every row in the scope to its position in
+// ids, in one transaction. The rows are locked and their current order must
+// still match fingerprint, so concurrent reorders cannot interleave: the
+// later one fails with ErrOrderChanged and has to re-read.
+func reorder(db *database.DB, scope orderScope, ids []int, fingerprint string, userID int) (*models.Ordering, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	current, err := scope.read(tx, true)
+	if err != nil {
+		return nil, err
+	}
+	if orderFingerprint(current) != fingerprint {
+		return nil, ErrOrderChanged
+	}
+	if err := checkOrder(current, ids); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+        UPDATE `+scope.table+` t
+        SET display_order = v.position - 1, updated_by = $2, updated_at = NOW()
+        FROM unnest($1::int[]) WITH ORDINALITY AS v(id, position)
+        WHERE t.id = v.id AND t.display_order <> v.position - 1
+    `, pq.Array(ids), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := scope.read(tx, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return newOrdering(updated), nil
+}
+
+// checkOrder verifies that ids lists every current row exactly once.
+func checkOrder(current []orderedRow, ids []int) error {
+	known := make(map[int]bool, len(current))
+	for _, row := range current {
+		known[row.id] = true
+	}
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if !known[id] {
+			return fmt.Errorf("%w: unknown id %d", ErrInvalidOrder, id)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: duplicate id %d", ErrInvalidOrder, id)
+		}
+		seen[id] = true
+	}
+	if len(ids) != len(current) {
+		return fmt.Errorf("%w: expected all %d ids, got %d", ErrInvalidOrder, len(current), len(ids))
+	}
+	return nil
+}
+
+func newOrdering(rows []orderedRow) *models.Ordering {
+	ids := make([]int, len(rows))
+	for i, row := range rows {
+		ids[i] = row.id
+	}
+	return &models.Ordering{IDs: ids, Fingerprint: orderFingerprint(rows)}
+}
+
+// orderFingerprint hashes the ids and their display_order so a client can
+// tell whether the order it read is still current.
+func orderFingerprint(rows []orderedRow) string {
+	h := sha256.New()
+	for _, row := range rows {
+		h.Write([]byte(strconv.Itoa(row.id) + ":" + strconv.Itoa(row.order) + ","))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
@@ -0,0 +1,195 @@
+package schemamigrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Object is a JSON object that remembers its key order, so migrated files
+// can be written back with minimal diffs. Values are *Object, []interface{},
+// string, json.Number, bool or nil.
+type Object struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+func NewObject() *Object {
+	return &Object{Values: make(map[string]interface{})}
+}
+
+func (o *Object) Get(key string) (interface{}, bool) {
+	v, ok := o.Values[key]
+	return v, ok
+}
+
+// Set replaces the value of key, appending the key if it is new.
+func (o *Object) Set(key string, value interface{}) {
+	if _, exists := o.Values[key]; !exists {
+		o.Keys = append(o.Keys, key)
+	}
+	o.Values[key] = value
+}
+
+// InsertAfter sets key right after the existing key after (or at the end
+// when after is absent).
+func (o *Object) InsertAfter(after, key string, value interface{}) {
+	if _, exists := o.Values[key]; exists {
+		o.Values[key] = value
+		return
+	}
+	o.Values[key] = value
+	for i, k := range o.Keys {
+		if k == after {
+			o.Keys = append(o.Keys[:i+1], append([]string{key}, o.Keys[i+1:]...)...)
+			return
+		}
+	}
+	o.Keys = append(o.Keys, key)
+}
+
+func (o *Object) Delete(key string) {
+	if _, exists := o.Values[key]; !exists {
+		return
+	}
+	delete(o.Values, key)
+	for i, k := range o.Keys {
+		if k == key {
+			o.Keys = append(o.Keys[:i], o.Keys[i+1:]...)
+			return
+		}
+	}
+}
+
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.Keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := encode(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := encode(o.Values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func encode(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Decode parses a JSON document preserving object key order and number text.
+func Decode(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	v, err := decodeValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after document")
+	}
+	return v, nil
+}
+
+func decodeValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			obj := NewObject()
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := keyTok.(string)
+				if !ok {
+					return nil, fmt.Errorf("object key is not a string")
+				}
+				value, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				obj.Set(key, value)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return obj, nil
+		case '[':
+			list := make([]interface{}, 0)
+			for dec.More() {
+				value, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, value)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return list, nil
+		}
+		return nil, fmt.Errorf("unexpected delimiter %v", t)
+	default:
+		return t, nil
+	}
+}
+
+// Encode writes a document with two-space indentation and a trailing newline,
+// matching the files under schemas/.
+func Encode(doc interface{}) ([]byte, error) {
+	compact, err := encode(doc)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, compact, "", "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// Walk calls fn for every object in the document with its JSONPath-like path.
+func Walk(v interface{}, path string, fn func(path string, obj *Object)) {
+	switch t := v.(type) {
+	case *Object:
+		fn(path, t)
+		for _, k := range t.Keys {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			Walk(t.Values[k], child, fn)
+		}
+	case []interface{}:
+		for i, item := range t {
+			Walk(item, fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package schemamigrate
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FormatKey is the top-level field recording which document format a schema
+// follows. Documents without it are format 1.
+const FormatKey = "format_version"
+
+// Change is one edit made by a transform, reported by dry runs.
+type Change struct {
+	Transform string      `json:"transform"`
+	Path      string      `json:"path"`
+	Before    interface{} `json:"before"`
+	After     interface{} `json:"after"`
+}
+
+// Transform upgrades documents from format From to From+1.
+type Transform struct {
+	Name        string
+	From        int
+	Description string
+	Apply       func(doc *Object) []Change
+}
+
+// Transforms is the ordered list of all known transforms. Add new ones at
+// the end with From set to the previous latest format.
+var Transforms = []Transform{
+	{
+		Name:        "edge_insets_object",
+		From:        1,
+		Description: `numeric "padding"/"margin" shorthand becomes {"all": n}`,
+		Apply:       edgeInsetsObject,
+	},
+}
+
+// LatestFormat is the format produced by applying every transform.
+func LatestFormat() int {
+	latest := 1
+	for _, t := range Transforms {
+		if t.From+1 > latest {
+			latest = t.From + 1
+		}
+	}
+	return latest
+}
+
+// FormatOf returns the document's format version.
+func FormatOf(doc *Object) int {
+	v, ok := doc.Get(FormatKey)
+	if !ok {
+		return 1
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return 1
+	}
+	i, err := n.Int64()
+	if err != nil {
+		return 1
+	}
+	return int(i)
+}
+
+// Migrate applies, in order, every transform needed to bring doc up to the
+// target format and returns the changes made. The document is edited in place.
+func Migrate(doc *Object, target int) ([]Change, error) {
+	current := FormatOf(doc)
+	if current > target {
+		return nil, fmt.Errorf("document is format %d, newer than target %d", current, target)
+	}
+
+	changes := make([]Change, 0)
+	for current < target {
+		var transform *Transform
+		for i := range Transforms {
+			if Transforms[i].From == current {
+				transform = &Transforms[i]
+				break
+			}
+		}
+		if transform == nil {
+			return nil, fmt.Errorf("no transform upgrades format %d", current)
+		}
+
+		changes = append(changes, transform.Apply(doc)...)
+		current++
+
+		before, _ := doc.Get(FormatKey)
+		doc.InsertAfter("version", FormatKey, json.Number(fmt.Sprint(current)))
+		changes = append(changes, Change{Transform: transform.Name, Path: FormatKey, Before: before, After: current})
+	}
+	return changes, nil
+}
+
+// MigrateBytes decodes, migrates and re-encodes a document. Output is nil
+// when the document was already at the target format.
+func MigrateBytes(data []byte, target int) ([]byte, []Change, error) {
+	decoded, err := Decode(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, ok := decoded.(*Object)
+	if !ok {
+		return nil, nil, fmt.Errorf("document is not a JSON object")
+	}
+
+	changes, err := Migrate(doc, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(changes) == 0 {
+		return nil, changes, nil
+	}
+
+	out, err := Encode(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, changes, nil
+}
+
+func edgeInsetsObject(doc *Object) []Change {
+	changes := make([]Change, 0)
+	Walk(doc, "", func(path string, obj *Object) {
+		for _, key := range []string{"padding", "margin"} {
+			v, ok := obj.Get(key)
+			if !ok {
+				continue
+			}
+			n, ok := v.(json.Number)
+			if !ok {
+				continue
+			}
+			replacement := NewObject()
+			replacement.Set("all", n)
+			obj.Set(key, replacement)
+
+			p := key
+			if path != "" {
+				p = path + "." + key
+			}
+			changes = append(changes, Change{Transform: "edge_insets_object", Path: p, Before: n, After: replacement})
+		}
+	})
+	return changes
+}
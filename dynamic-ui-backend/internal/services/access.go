@@ -0,0 +1,74 @@
+package services
+
+import "fmt"
+
+// Screen access policies, declared in a screen's top-level "access" block:
+//
+//	"access": {"policy": "authenticated"}
+//	"access": {"policy": "roles", "roles": ["admin"]}
+//
+// Screens without the block are public.
+const (
+	AccessPublic        = "public"
+	AccessAuthenticated = "authenticated"
+	AccessRoles         = "roles"
+)
+
+type AccessPolicy struct {
+	Policy string
+	Roles  []string
+}
+
+// ScreenAccess reads a screen's access policy. A malformed block is an
+// error; callers should treat the screen as restricted.
+func ScreenAccess(doc map[string]interface{}) (AccessPolicy, error) {
+	raw, ok := doc["access"]
+	if !ok {
+		return AccessPolicy{Policy: AccessPublic}, nil
+	}
+	block, ok := raw.(map[string]interface{})
+	if !ok {
+		return AccessPolicy{}, fmt.Errorf("access must be an object")
+	}
+
+	policy := AccessPolicy{}
+	policy.Policy, _ = block["policy"].(string)
+	if list, ok := block["roles"].([]interface{}); ok {
+		for _, r := range list {
+			role, ok := r.(string)
+			if !ok || role == "" {
+				return AccessPolicy{}, fmt.Errorf("access.roles must be a list of role names")
+			}
+			policy.Roles = append(policy.Roles, role)
+		}
+	}
+
+	switch policy.Policy {
+	case AccessPublic, AccessAuthenticated:
+	case AccessRoles:
+		if len(policy.Roles) == 0 {
+			return AccessPolicy{}, fmt.Errorf("access policy 'roles' needs at least one role")
+		}
+	default:
+		return AccessPolicy{}, fmt.Errorf("unknown access policy '%s'", policy.Policy)
+	}
+	return policy, nil
+}
+
+func (p AccessPolicy) IsPublic() bool {
+	return p.Policy == AccessPublic
+}
+
+// AllowsRole reports whether an authenticated user with the role may see
+// the screen.
+func (p AccessPolicy) AllowsRole(role string) bool {
+	if p.Policy != AccessRoles {
+		return true
+	}
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
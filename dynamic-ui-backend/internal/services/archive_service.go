@@ -0,0 +1,278 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+const (
+	archiveFormat        = 1
+	archiveManifestName  = "manifest.json"
+	archiveSignatureName = "manifest.sig"
+	maxArchiveEntrySize  = 10 << 20
+	maxArchiveEntries    = 1000
+	maxArchiveTotalSize  = 100 << 20
+)
+
+type ArchiveManifest struct {
+	Format     int                   `json:"format"`
+	Version    string                `json:"version"`
+	ExportedAt time.Time             `json:"exported_at"`
+	Files      []ArchiveManifestFile `json:"files"`
+}
+
+type ArchiveManifestFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size"`
+}
+
+// ImportReport describes what an archive import changed (or, for a dry run,
+// would change) in the drafts of a version.
+type ImportReport struct {
+	Version   string   `json:"version"`
+	DryRun    bool     `json:"dry_run"`
+	Added     []string `json:"added"`
+	Modified  []string `json:"modified"`
+	Unchanged []string `json:"unchanged"`
+	// NotInArchive lists published documents the archive does not contain.
+	// They are left untouched.
+	NotInArchive []string `json:"not_in_archive"`
+}
+
+// ArchiveService moves complete schema sets between environments as signed
+// zip archives.
+type ArchiveService struct {
+	uiService  *UIService
+	signingKey []byte
+}
+
+func NewArchiveService(uiService *UIService) *ArchiveService {
+	return &ArchiveService{
+		uiService:  uiService,
+		signingKey: []byte(os.Getenv("ARCHIVE_SIGNING_KEY")),
+	}
+}
+
+// Export writes every published document of a version into a zip archive
+// along with a manifest and its HMAC-SHA256 signature.
+func (s *ArchiveService) Export(version string, w io.Writer) (*ArchiveManifest, error) {
+	if len(s.signingKey) == 0 {
+		return nil, fmt.Errorf("ARCHIVE_SIGNING_KEY is not configured")
+	}
+
+	files, err := s.uiService.PublishedFiles(version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schemas: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no schemas found for version '%s'", version)
+	}
+
+	manifest := &ArchiveManifest{
+		Format:     archiveFormat,
+		Version:    version,
+		ExportedAt: time.Now().UTC(),
+		Files:      make([]ArchiveManifestFile, 0, len(files)),
+	}
+	for _, f := range files {
+		sum := sha256.Sum256(f.Data)
+		manifest.Files = append(manifest.Files, ArchiveManifestFile{
+			Path:   f.Path,
+			SHA256: hex.EncodeToString(sum[:]),
+			Size:   len(f.Data),
+		})
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	zw := zip.NewWriter(w)
+	entries := []SchemaFile{
+		{Path: archiveManifestName, Data: manifestData},
+		{Path: archiveSignatureName, Data: []byte(s.sign(manifestData))},
+	}
+	entries = append(entries, files...)
+
+	for _, entry := range entries {
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     entry.Path,
+			Method:   zip.Deflate,
+			Modified: manifest.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write(entry.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Import verifies an archive and writes its documents into the drafts of the
+// given version. With dryRun set nothing is written and only the report is
+// produced.
+func (s *ArchiveService) Import(version string, data []byte, dryRun bool) (*ImportReport, error) {
+	if len(s.signingKey) == 0 {
+		return nil, fmt.Errorf("ARCHIVE_SIGNING_KEY is not configured")
+	}
+	if !IsValidName(version) {
+		return nil, fmt.Errorf("invalid version '%s'", version)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+
+	if len(zr.File) > maxArchiveEntries {
+		return nil, fmt.Errorf("archive has more than %d entries", maxArchiveEntries)
+	}
+
+	contents := make(map[string][]byte)
+	var total int
+	for _, f := range zr.File {
+		if _, dup := contents[f.Name]; dup {
+			return nil, fmt.Errorf("duplicate archive entry '%s'", f.Name)
+		}
+		if f.UncompressedSize64 > maxArchiveEntrySize {
+			return nil, fmt.Errorf("archive entry '%s' is too large", f.Name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s': %w", f.Name, err)
+		}
+		body, err := io.ReadAll(io.LimitReader(rc, maxArchiveEntrySize+1))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s': %w", f.Name, err)
+		}
+		if len(body) > maxArchiveEntrySize {
+			return nil, fmt.Errorf("archive entry '%s' is too large", f.Name)
+		}
+		if total += len(body); total > maxArchiveTotalSize {
+			return nil, fmt.Errorf("archive is too large uncompressed")
+		}
+		contents[f.Name] = body
+	}
+
+	manifestData, ok := contents[archiveManifestName]
+	if !ok {
+		return nil, fmt.Errorf("archive has no %s", archiveManifestName)
+	}
+	signature, ok := contents[archiveSignatureName]
+	if !ok {
+		return nil, fmt.Errorf("archive has no %s", archiveSignatureName)
+	}
+	if !hmac.Equal(bytes.TrimSpace(signature), []byte(s.sign(manifestData))) {
+		return nil, fmt.Errorf("archive signature does not match")
+	}
+
+	var manifest ArchiveManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if manifest.Format != archiveFormat {
+		return nil, fmt.Errorf("unsupported archive format %d", manifest.Format)
+	}
+	if manifest.Version != version {
+		return nil, fmt.Errorf("archive was exported for version '%s', not '%s'", manifest.Version, version)
+	}
+	if len(contents) != len(manifest.Files)+2 {
+		return nil, fmt.Errorf("archive contains files not listed in the manifest")
+	}
+
+	files := make([]SchemaFile, 0, len(manifest.Files))
+	for _, mf := range manifest.Files {
+		if _, _, err := ParseSchemaPath(mf.Path); err != nil {
+			return nil, err
+		}
+		body, ok := contents[mf.Path]
+		if !ok {
+			return nil, fmt.Errorf("manifest lists missing file '%s'", mf.Path)
+		}
+		sum := sha256.Sum256(body)
+		if hex.EncodeToString(sum[:]) != mf.SHA256 {
+			return nil, fmt.Errorf("checksum mismatch for '%s'", mf.Path)
+		}
+		if !json.Valid(body) {
+			return nil, fmt.Errorf("'%s' is not valid JSON", mf.Path)
+		}
+		files = append(files, SchemaFile{Path: mf.Path, Data: body})
+	}
+
+	report := &ImportReport{
+		Version:      version,
+		DryRun:       dryRun,
+		Added:        make([]string, 0),
+		Modified:     make([]string, 0),
+		Unchanged:    make([]string, 0),
+		NotInArchive: make([]string, 0),
+	}
+
+	inArchive := make(map[string]bool, len(files))
+	for _, f := range files {
+		inArchive[f.Path] = true
+
+		// Compare against the pending draft if there is one, otherwise
+		// against what is currently published.
+		current, err := s.uiService.ReadDraft(version, f.Path)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			if current, err = s.uiService.ReadPublished(version, f.Path); err != nil {
+				return nil, err
+			}
+		}
+
+		switch {
+		case current == nil:
+			report.Added = append(report.Added, f.Path)
+		case SameDocument(current, f.Data):
+			report.Unchanged = append(report.Unchanged, f.Path)
+			continue
+		default:
+			report.Modified = append(report.Modified, f.Path)
+		}
+
+		if !dryRun {
+			if err := s.uiService.WriteDraft(version, f.Path, f.Data); err != nil {
+				return nil, fmt.Errorf("failed to write draft '%s': %w", f.Path, err)
+			}
+		}
+	}
+
+	published, err := s.uiService.PublishedFiles(version)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range published {
+		if !inArchive[f.Path] {
+			report.NotInArchive = append(report.NotInArchive, f.Path)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *ArchiveService) sign(data []byte) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
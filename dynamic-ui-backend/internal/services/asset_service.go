@@ -0,0 +1,158 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+)
+
+const maxAssetSize = 2 << 20
+
+var assetNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_]{0,99}$`)
+
+// AssetService validates and stores Lottie animations and SVG icons under
+// the uploads directory and registers them as numbered versions.
+type AssetService struct {
+	repo      *repositories.AssetRepository
+	uploadDir string
+	baseURL   string
+}
+
+func NewAssetService(repo *repositories.AssetRepository) *AssetService {
+	uploadDir := os.Getenv("UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = "./uploads"
+	}
+	baseURL := os.Getenv("BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+
+	os.MkdirAll(filepath.Join(uploadDir, "assets", models.AssetKindLottie), 0755)
+	os.MkdirAll(filepath.Join(uploadDir, "assets", models.AssetKindIcon), 0755)
+
+	return &AssetService{repo: repo, uploadDir: uploadDir, baseURL: baseURL}
+}
+
+// Register validates the file and stores it as a new version. Uploading the
+// same content as the current version returns that version unchanged.
+func (s *AssetService) Register(name, kind string, data []byte, userID int) (asset *models.Asset, created bool, err error) {
+	if !assetNamePattern.MatchString(name) {
+		return nil, false, fmt.Errorf("name must be lowercase letters, digits and '_'")
+	}
+	if len(data) > maxAssetSize {
+		return nil, false, fmt.Errorf("file exceeds %d bytes", maxAssetSize)
+	}
+
+	var ext string
+	switch kind {
+	case models.AssetKindLottie:
+		if err := validateLottie(data); err != nil {
+			return nil, false, err
+		}
+		ext = ".json"
+	case models.AssetKindIcon:
+		if err := validateSVG(data); err != nil {
+			return nil, false, err
+		}
+		ext = ".svg"
+	default:
+		return nil, false, fmt.Errorf("kind must be '%s' or '%s'", models.AssetKindLottie, models.AssetKindIcon)
+	}
+
+	hash := ContentHash(data)
+	if current, err := s.repo.Get(kind, name, 0); err == nil && current.ContentHash == hash {
+		return current, false, nil
+	}
+
+	filename := fmt.Sprintf("%s_%s%s", name, hash[:12], ext)
+	if err := WriteFileAtomic(filepath.Join(s.uploadDir, "assets", kind, filename), data); err != nil {
+		return nil, false, fmt.Errorf("failed to save file: %w", err)
+	}
+
+	asset, err = s.repo.Create(&models.Asset{
+		Name:        name,
+		Kind:        kind,
+		URL:         fmt.Sprintf("%s/uploads/assets/%s/%s", s.baseURL, kind, filename),
+		ContentHash: hash,
+		SizeBytes:   int64(len(data)),
+	}, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	return asset, true, nil
+}
+
+// validateLottie checks for the top-level fields every Bodymovin export has.
+func validateLottie(data []byte) error {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("lottie file is not valid JSON: %w", err)
+	}
+	for _, key := range []string{"v", "fr", "ip", "op", "w", "h"} {
+		if _, ok := doc[key]; !ok {
+			return fmt.Errorf("lottie file is missing '%s'", key)
+		}
+	}
+	if _, ok := doc["layers"].([]interface{}); !ok {
+		return fmt.Errorf("lottie file has no layers")
+	}
+	return nil
+}
+
+// validateSVG requires an <svg> root and rejects scripts, event handler
+// attributes and external references, since the files are served as-is.
+func validateSVG(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	sawRoot := false
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("icon is not valid SVG: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if !sawRoot {
+				if name != "svg" {
+					return fmt.Errorf("icon root element must be <svg>")
+				}
+				sawRoot = true
+			}
+			if name == "script" || name == "foreignobject" {
+				return fmt.Errorf("icon must not contain <%s>", t.Name.Local)
+			}
+			for _, attr := range t.Attr {
+				attrName := strings.ToLower(attr.Name.Local)
+				value := strings.ToLower(strings.TrimSpace(attr.Value))
+				if strings.HasPrefix(attrName, "on") {
+					return fmt.Errorf("icon must not contain event handler '%s'", attr.Name.Local)
+				}
+				if attrName == "href" && !strings.HasPrefix(value, "#") {
+					return fmt.Errorf("icon must not reference external resources")
+				}
+			}
+		case xml.Directive:
+			return fmt.Errorf("icon must not contain DTD declarations")
+		}
+	}
+
+	if !sawRoot {
+		return fmt.Errorf("icon has no <svg> element")
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const bundleManifestName = "bundle.json"
+
+// zipEpoch is used as every entry's modification time so identical content
+// always produces a byte-identical archive.
+var zipEpoch = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type BundleManifest struct {
+	Version       string       `json:"version"`
+	BundleHash    string       `json:"bundle_hash"`
+	Files         []BundleFile `json:"files"`
+	MissingImages []string     `json:"missing_images"`
+}
+
+type BundleFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size"`
+}
+
+// BundleService builds the offline fallback bundle the mobile app embeds:
+// every published document of a version plus the uploaded images they
+// reference.
+type BundleService struct {
+	uiService *UIService
+	uploadDir string
+	baseURL   string
+	cache     *cache.Cache
+}
+
+func NewBundleService(uiService *UIService) *BundleService {
+	uploadDir := os.Getenv("UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = "./uploads"
+	}
+	baseURL := os.Getenv("BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	return &BundleService{
+		uiService: uiService,
+		uploadDir: uploadDir,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		cache:     cache.New(30*time.Second, time.Minute),
+	}
+}
+
+// collect returns the bundle contents sorted by path, plus the referenced
+// images that could not be found in the uploads directory.
+func (s *BundleService) collect(version string) ([]SchemaFile, []string, error) {
+	docs, err := s.uiService.PublishedFiles(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(docs) == 0 {
+		return nil, nil, fmt.Errorf("no schemas found for version '%s'", version)
+	}
+
+	decodedDocs := make(map[string]interface{}, len(docs))
+	restricted := make(map[string]bool)
+	for _, doc := range docs {
+		var decoded interface{}
+		if err := json.Unmarshal(doc.Data, &decoded); err != nil {
+			return nil, nil, fmt.Errorf("'%s' is not valid JSON: %w", doc.Path, err)
+		}
+		decodedDocs[doc.Path] = decoded
+
+		// The bundle is embedded in the app and readable by anyone, so it
+		// only carries public screens.
+		if kind, name, _ := ParseSchemaPath(doc.Path); kind == "screens" {
+			screen, _ := decoded.(map[string]interface{})
+			if policy, err := ScreenAccess(screen); err != nil || !policy.IsPublic() {
+				restricted[name] = true
+			}
+		}
+	}
+
+	files := make([]SchemaFile, 0, len(docs))
+	images := make(map[string]bool)
+	for _, doc := range docs {
+		kind, name, _ := ParseSchemaPath(doc.Path)
+		switch {
+		// Templates are admin-only starting points, never rendered by the app.
+		case kind == TemplatesKind,
+			kind == "screens" && restricted[name],
+			kind == OverridesKind && restricted[OverrideScreen(name)]:
+			continue
+		}
+		files = append(files, doc)
+		s.collectImages(decodedDocs[doc.Path], images)
+	}
+
+	missing := make([]string, 0)
+	for _, rel := range SortedKeys(images) {
+		data, err := os.ReadFile(filepath.Join(s.uploadDir, filepath.FromSlash(rel)))
+		if err != nil {
+			missing = append(missing, rel)
+			continue
+		}
+		files = append(files, SchemaFile{Path: "images/" + rel, Data: data})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, missing, nil
+}
+
+// collectImages records every string value pointing into the uploads area,
+// as a path relative to the upload directory.
+func (s *BundleService) collectImages(v interface{}, images map[string]bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for _, child := range t {
+			s.collectImages(child, images)
+		}
+	case []interface{}:
+		for _, child := range t {
+			s.collectImages(child, images)
+		}
+	case string:
+		rel := ""
+		if strings.HasPrefix(t, s.baseURL+"/uploads/") {
+			rel = strings.TrimPrefix(t, s.baseURL+"/uploads/")
+		} else if strings.HasPrefix(t, "/uploads/") {
+			rel = strings.TrimPrefix(t, "/uploads/")
+		}
+		if rel == "" {
+			return
+		}
+		rel = path.Clean(rel)
+		if rel == "." || strings.HasPrefix(rel, "..") || path.IsAbs(rel) {
+			return
+		}
+		images[rel] = true
+	}
+}
+
+func bundleManifest(version string, files []SchemaFile, missing []string) *BundleManifest {
+	manifest := &BundleManifest{
+		Version:       version,
+		Files:         make([]BundleFile, 0, len(files)),
+		MissingImages: missing,
+	}
+
+	// The bundle hash covers paths and contents, so renames count as changes.
+	h := sha256.New()
+	for _, f := range files {
+		sum := sha256.Sum256(f.Data)
+		entry := BundleFile{Path: f.Path, SHA256: hex.EncodeToString(sum[:]), Size: len(f.Data)}
+		manifest.Files = append(manifest.Files, entry)
+		fmt.Fprintf(h, "%s\x00%s\n", entry.Path, entry.SHA256)
+	}
+	manifest.BundleHash = hex.EncodeToString(h.Sum(nil))
+	return manifest
+}
+
+// CurrentHash returns the hash a freshly built bundle would have.
+func (s *BundleService) CurrentHash(version string) (string, error) {
+	if cached, found := s.cache.Get(version); found {
+		return cached.(string), nil
+	}
+	files, missing, err := s.collect(version)
+	if err != nil {
+		return "", err
+	}
+	hash := bundleManifest(version, files, missing).BundleHash
+	s.cache.Set(version, hash, cache.DefaultExpiration)
+	return hash, nil
+}
+
+// Write builds the bundle as a deterministic zip archive: entries sorted by
+// path, fixed timestamps and a manifest without build-time data.
+func (s *BundleService) Write(version string, w io.Writer) (*BundleManifest, error) {
+	files, missing, err := s.collect(version)
+	if err != nil {
+		return nil, err
+	}
+	manifest := bundleManifest(version, files, missing)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	zw := zip.NewWriter(w)
+	entries := append([]SchemaFile{{Path: bundleManifestName, Data: manifestData}}, files...)
+	for _, entry := range entries {
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     entry.Path,
+			Method:   zip.Deflate,
+			Modified: zipEpoch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write(entry.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	canariesCacheKey = "schema_canaries"
+
+	defaultCanaryErrorThreshold = 20
+	maxRenderErrorMessage       = 1000
+)
+
+// runningCanary is an active canary with its document decoded once.
+type runningCanary struct {
+	canary   models.SchemaCanary
+	document map[string]interface{}
+}
+
+// CanaryService serves candidate screen documents to a stable share of
+// devices and halts the rollout when too many devices report render errors.
+type CanaryService struct {
+	repo      *repositories.CanaryRepository
+	uiService *UIService
+	cache     *cache.Cache
+}
+
+func NewCanaryService(repo *repositories.CanaryRepository, uiService *UIService) *CanaryService {
+	return &CanaryService{repo: repo, uiService: uiService, cache: cache.New(10*time.Second, time.Minute)}
+}
+
+func canaryKey(version, screen string) string {
+	return version + "/" + screen
+}
+
+func canaryPath(screen string) string {
+	return "screens/" + screen + ".json"
+}
+
+func (s *CanaryService) running() (map[string]runningCanary, error) {
+	if cached, found := s.cache.Get(canariesCacheKey); found {
+		return cached.(map[string]runningCanary), nil
+	}
+	canaries, err := s.repo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+
+	running := make(map[string]runningCanary, len(canaries))
+	for _, c := range canaries {
+		var doc map[string]interface{}
+		if err := json.Unmarshal([]byte(c.Document), &doc); err != nil {
+			continue
+		}
+		running[canaryKey(c.Version, c.Screen)] = runningCanary{canary: c, document: doc}
+	}
+	s.cache.Set(canariesCacheKey, running, cache.DefaultExpiration)
+	return running, nil
+}
+
+// Invalidate drops the cached canary set after a change.
+func (s *CanaryService) Invalidate() {
+	s.cache.Delete(canariesCacheKey)
+}
+
+// ForClient returns the canary document for a device inside the rollout
+// share. The document is shared and must be copied before modifying it.
+// Devices without an ID always get the published document.
+func (s *CanaryService) ForClient(version, screen, deviceID string) (*models.SchemaCanary, map[string]interface{}, bool) {
+	if deviceID == "" {
+		return nil, nil, false
+	}
+	running, err := s.running()
+	if err != nil {
+		return nil, nil, false
+	}
+	rc, ok := running[canaryKey(version, screen)]
+	if !ok || !rc.serves(deviceID) {
+		return nil, nil, false
+	}
+	return &rc.canary, rc.document, true
+}
+
+// serves reports whether the device is inside the rollout share. Salting
+// with the canary ID keeps the same devices in the rollout as the percentage
+// grows, and picks a fresh sample for the next canary.
+func (rc runningCanary) serves(deviceID string) bool {
+	return InRollout(fmt.Sprintf("canary:%d", rc.canary.ID), deviceID, rc.canary.Percentage)
+}
+
+func validateCanarySettings(percentage, errorThreshold int) error {
+	if percentage < 0 || percentage > 100 {
+		return fmt.Errorf("percentage must be between 0 and 100")
+	}
+	if errorThreshold < 1 {
+		return fmt.Errorf("error_threshold must be at least 1")
+	}
+	return nil
+}
+
+// Start turns the screen's draft into a canary. The draft is consumed, the
+// same way publishing it would.
+func (s *CanaryService) Start(req *models.StartCanaryRequest, userID int) (*models.SchemaCanary, error) {
+	if req.Version == "" {
+		req.Version = "v1"
+	}
+	if req.ErrorThreshold == 0 {
+		req.ErrorThreshold = defaultCanaryErrorThreshold
+	}
+	if !IsValidName(req.Version) || !IsValidName(req.Screen) {
+		return nil, fmt.Errorf("invalid version or screen")
+	}
+	if err := validateCanarySettings(req.Percentage, req.ErrorThreshold); err != nil {
+		return nil, err
+	}
+
+	path := canaryPath(req.Screen)
+	published, err := s.uiService.ReadPublished(req.Version, path)
+	if err != nil {
+		return nil, err
+	}
+	if published == nil {
+		return nil, fmt.Errorf("screen '%s' is not published yet; publish it directly", req.Screen)
+	}
+
+	draft, err := s.uiService.ReadDraft(req.Version, path)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, fmt.Errorf("draft '%s' not found for version '%s'", path, req.Version)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(draft, &doc); err != nil {
+		return nil, fmt.Errorf("draft '%s' is not a JSON object", path)
+	}
+
+	running, err := s.running()
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := running[canaryKey(req.Version, req.Screen)]; exists {
+		return nil, fmt.Errorf("a canary is already running for '%s'", req.Screen)
+	}
+
+	canary, err := s.repo.Create(&models.SchemaCanary{
+		Version:        req.Version,
+		Screen:         req.Screen,
+		Revision:       ContentHash(draft),
+		Document:       string(draft),
+		Percentage:     req.Percentage,
+		ErrorThreshold: req.ErrorThreshold,
+	}, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create canary: %w", err)
+	}
+
+	if err := s.uiService.DeleteDraft(req.Version, path); err != nil {
+		return nil, err
+	}
+	s.changed(canary)
+	return canary, nil
+}
+
+// Update adjusts the rollout percentage or error threshold.
+func (s *CanaryService) Update(id int, req *models.UpdateCanaryRequest, userID int) (*models.SchemaCanary, error) {
+	current, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	percentage, threshold := current.Percentage, current.ErrorThreshold
+	if req.Percentage != nil {
+		percentage = *req.Percentage
+	}
+	if req.ErrorThreshold != nil {
+		threshold = *req.ErrorThreshold
+	}
+	if err := validateCanarySettings(percentage, threshold); err != nil {
+		return nil, err
+	}
+
+	canary, err := s.repo.Update(id, req, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.changed(canary)
+	return canary, nil
+}
+
+// Promote publishes the canary document to every device. The canary is
+// only marked promoted once the document is published; if publishing fails
+// it keeps running.
+func (s *CanaryService) Promote(id, userID int) (*models.SchemaCanary, error) {
+	current, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status != models.CanaryStatusActive {
+		return nil, fmt.Errorf("canary is not running")
+	}
+	if err := s.uiService.Publish(current.Version, canaryPath(current.Screen), []byte(current.Document)); err != nil {
+		return nil, fmt.Errorf("failed to publish canary document: %w", err)
+	}
+
+	canary, err := s.repo.Finish(id, models.CanaryStatusPromoted, "", &userID)
+	if err != nil {
+		// Halted or aborted while publishing: the document is published
+		// regardless, so the canary record no longer matters.
+		s.Invalidate()
+		return nil, fmt.Errorf("document published but the canary could not be marked promoted: %w", err)
+	}
+	s.Invalidate()
+	return canary, nil
+}
+
+// Abort stops the canary; every device goes back to the published document.
+func (s *CanaryService) Abort(id, userID int) (*models.SchemaCanary, error) {
+	canary, err := s.repo.Finish(id, models.CanaryStatusAborted, "", &userID)
+	if err != nil {
+		return nil, err
+	}
+	s.changed(canary)
+	return canary, nil
+}
+
+// ReportError records a client render error against the canary serving the
+// reported revision and halts it once more devices than the threshold have
+// reported, counting devices that share an IP as one. Reports for revisions that are not in a running canary, or from
+// devices outside its rollout share, are ignored. The returned canary is
+// non-nil only when this report halted the rollout.
+func (s *CanaryService) ReportError(report *models.RenderErrorReport, who Submitter) (*models.SchemaCanary, error) {
+	if report.Version == "" {
+		report.Version = "v1"
+	}
+	running, err := s.running()
+	if err != nil {
+		return nil, err
+	}
+	rc, ok := running[canaryKey(report.Version, report.Screen)]
+	if !ok || rc.canary.Revision != report.Revision || !rc.serves(who.DeviceID) {
+		return nil, nil
+	}
+
+	message := report.Message
+	if len(message) > maxRenderErrorMessage {
+		message = message[:maxRenderErrorMessage]
+	}
+	count, err := s.repo.RecordError(rc.canary.ID, who.DeviceID, who.IP, message)
+	if err != nil {
+		return nil, err
+	}
+	if count <= rc.canary.ErrorThreshold {
+		return nil, nil
+	}
+
+	reason := fmt.Sprintf("%d devices or networks reported render errors, exceeding the threshold of %d", count, rc.canary.ErrorThreshold)
+	halted, err := s.repo.Finish(rc.canary.ID, models.CanaryStatusHalted, reason, nil)
+	if err != nil {
+		// Another report or an admin action finished it first.
+		s.Invalidate()
+		return nil, nil
+	}
+	s.changed(halted)
+	return halted, nil
+}
+
+// changed refreshes the canary cache and tells streaming clients of the
+// screen to refetch, since the document they should get may have changed.
+func (s *CanaryService) changed(canary *models.SchemaCanary) {
+	s.Invalidate()
+	s.uiService.events.Publish(SchemaEvent{
+		Type:    SchemaEventCanary,
+		Version: canary.Version,
+		Path:    canaryPath(canary.Screen),
+		Screen:  canary.Screen,
+		Hash:    canary.Revision,
+	})
+}
@@ -0,0 +1,53 @@
+package services
+
+import "dynamic-ui-backend/internal/models"
+
+// BuildCategoryTree nests categories under their parents, keeping the input
+// order among siblings. Categories whose parent is not in the list, such as
+// the children of an inactive category, are left out with their subtrees.
+func BuildCategoryTree(categories []models.Category) []models.Category {
+	children := make(map[int][]int)
+	present := make(map[int]bool, len(categories))
+	for _, c := range categories {
+		present[c.ID] = true
+	}
+	roots := make([]int, 0)
+	for i, c := range categories {
+		switch {
+		case c.ParentID == nil:
+			roots = append(roots, i)
+		case present[*c.ParentID]:
+			children[*c.ParentID] = append(children[*c.ParentID], i)
+		}
+	}
+
+	visited := make(map[int]bool, len(categories))
+	var build func(indexes []int) []models.Category
+	build = func(indexes []int) []models.Category {
+		nodes := make([]models.Category, 0, len(indexes))
+		for _, i := range indexes {
+			node := categories[i]
+			if visited[node.ID] {
+				continue
+			}
+			visited[node.ID] = true
+			node.Children = build(children[node.ID])
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	return build(roots)
+}
+
+// FindCategory returns the node with the ID from a tree, with its subtree.
+func FindCategory(tree []models.Category, id int) (*models.Category, bool) {
+	for i := range tree {
+		if tree[i].ID == id {
+			return &tree[i], true
+		}
+		if found, ok := FindCategory(tree[i].Children, id); ok {
+			return found, true
+		}
+	}
+	return nil, false
+}
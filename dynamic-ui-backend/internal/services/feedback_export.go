@@ -0,0 +1,181 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"dynamic-ui-backend/internal/models"
+)
+
+// Feedback export formats.
+const (
+	FeedbackExportCSV   = "csv"
+	FeedbackExportJSONL = "jsonl"
+)
+
+// feedbackFlushEvery is how many rows are buffered before flushing to the
+// client.
+const feedbackFlushEvery = 200
+
+// feedbackExportColumns precede the answer columns in CSV exports.
+var feedbackExportColumns = []string{"id", "created_at", "screen_id", "schema_version", "revision", "device_id", "platform", "app_version", "user_id"}
+
+// FeedbackExport streams the responses matching a filter. It is prepared
+// before anything is written, so setup errors can still be reported with a
+// proper status.
+type FeedbackExport struct {
+	service *FeedbackService
+	filter  *models.FeedbackFilter
+	format  string
+	keys    []string
+}
+
+// Export prepares an export in the given format. CSV exports get one column
+// per state key: the screen's feedback form keys, then any other key found
+// in the responses, sorted.
+func (s *FeedbackService) Export(filter *models.FeedbackFilter, format string) (*FeedbackExport, error) {
+	export := &FeedbackExport{service: s, filter: filter, format: format}
+	switch format {
+	case FeedbackExportJSONL:
+		return export, nil
+	case FeedbackExportCSV:
+	default:
+		return nil, fmt.Errorf("invalid format '%s'", format)
+	}
+
+	answered, err := s.repo.AnswerKeys(filter)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(answered)
+
+	// A screen that is no longer published still exports, with its
+	// columns in alphabetical order.
+	seen := make(map[string]bool)
+	if schema, err := s.uiService.GetScreenSchema(filter.Screen, filter.Version); err == nil {
+		if form, err := feedbackForm(schema); err == nil && form != nil {
+			for _, key := range form.Keys() {
+				seen[key] = true
+				export.keys = append(export.keys, key)
+			}
+		}
+	}
+	for _, key := range answered {
+		if !seen[key] {
+			export.keys = append(export.keys, key)
+		}
+	}
+	return export, nil
+}
+
+// ContentType returns the MIME type of the export.
+func (e *FeedbackExport) ContentType() string {
+	if e.format == FeedbackExportCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// Filename suggests a download name, e.g. survey-v1-feedback.csv.
+func (e *FeedbackExport) Filename() string {
+	return fmt.Sprintf("%s-%s-feedback.%s", e.filter.Screen, e.filter.Version, e.format)
+}
+
+// Stream writes the responses to w. flush, when set, is called every few
+// hundred rows so the client receives data as it is read.
+func (e *FeedbackExport) Stream(w io.Writer, flush func()) error {
+	if flush == nil {
+		flush = func() {}
+	}
+	if e.format == FeedbackExportJSONL {
+		return e.writeJSONL(w, flush)
+	}
+	return e.writeCSV(w, flush)
+}
+
+func (e *FeedbackExport) writeJSONL(w io.Writer, flush func()) error {
+	encoder := json.NewEncoder(w)
+	rows := 0
+	return e.service.repo.Each(e.filter, func(r *models.FeedbackResponse) error {
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+		if rows++; rows%feedbackFlushEvery == 0 {
+			flush()
+		}
+		return nil
+	})
+}
+
+func (e *FeedbackExport) writeCSV(w io.Writer, flush func()) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append(append([]string{}, feedbackExportColumns...), e.keys...)); err != nil {
+		return err
+	}
+
+	record := make([]string, len(feedbackExportColumns)+len(e.keys))
+	rows := 0
+	err := e.service.repo.Each(e.filter, func(r *models.FeedbackResponse) error {
+		var answers map[string]interface{}
+		if err := json.Unmarshal(r.Answers, &answers); err != nil {
+			return fmt.Errorf("response %d has invalid answers: %w", r.ID, err)
+		}
+
+		userID := ""
+		if r.UserID != nil {
+			userID = strconv.Itoa(*r.UserID)
+		}
+		copy(record, []string{strconv.Itoa(r.ID), r.CreatedAt.UTC().Format(time.RFC3339), r.ScreenID, r.SchemaVersion,
+			r.Revision, r.DeviceID, r.Platform, r.AppVersion, userID})
+		for i, key := range e.keys {
+			record[len(feedbackExportColumns)+i] = csvCell(answers[key])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+
+		if rows++; rows%feedbackFlushEvery == 0 {
+			writer.Flush()
+			flush()
+		}
+		return writer.Error()
+	})
+	writer.Flush()
+	if err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// csvCell formats an answer for a spreadsheet. Multi-selections are joined
+// with "; ". Text that a spreadsheet would evaluate as a formula is
+// prefixed with a quote.
+func csvCell(v interface{}) string {
+	var s string
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		s = t
+	case []interface{}:
+		parts := make([]string, 0, len(t))
+		for _, item := range t {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		s = strings.Join(parts, "; ")
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		s = fmt.Sprint(t)
+	}
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		s = "'" + s
+	}
+	return s
+}
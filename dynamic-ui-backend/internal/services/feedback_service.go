@@ -0,0 +1,100 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"dynamic-ui-backend/internal/forms"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+)
+
+// FeedbackService stores survey submissions after checking them against the
+// inputs declared by the screen they were submitted from.
+type FeedbackService struct {
+	repo      *repositories.FeedbackRepository
+	uiService *UIService
+}
+
+func NewFeedbackService(repo *repositories.FeedbackRepository, uiService *UIService) *FeedbackService {
+	return &FeedbackService{repo: repo, uiService: uiService}
+}
+
+// Submit validates the state against the screen document the client was
+// served and stores it. Invalid submissions return field errors and are not
+// stored.
+func (s *FeedbackService) Submit(req *models.SubmitFeedbackRequest, schema map[string]interface{}, revision string, client models.ClientContext, userID *int) (*models.FeedbackResponse, []forms.FieldError, error) {
+	answers, fieldErrors, err := ValidateFeedback(schema, req.State)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(fieldErrors) > 0 {
+		return nil, fieldErrors, nil
+	}
+
+	data, err := json.Marshal(answers)
+	if err != nil {
+		return nil, nil, err
+	}
+	screenID, _ := schema["screen_id"].(string)
+	schemaVersion, _ := schema["version"].(string)
+
+	response, err := s.repo.Create(&models.FeedbackResponse{
+		Screen:        req.Screen,
+		ScreenID:      screenID,
+		Version:       req.Version,
+		SchemaVersion: schemaVersion,
+		Revision:      revision,
+		Answers:       data,
+		DeviceID:      client.DeviceID,
+		Platform:      client.Platform,
+		AppVersion:    client.AppVersion,
+		UserID:        userID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to store feedback: %w", err)
+	}
+	return response, nil, nil
+}
+
+// ValidateFeedback checks a submitted state map against the form collected
+// by the screen's submit_feedback actions and returns the accepted answers.
+// See the forms package for the rules.
+func ValidateFeedback(schema map[string]interface{}, state map[string]interface{}) (map[string]interface{}, []forms.FieldError, error) {
+	form, err := feedbackForm(schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	if form == nil {
+		return nil, []forms.FieldError{{Code: forms.CodeUnknown, Message: "screen does not accept feedback"}}, nil
+	}
+	answers, fieldErrors := form.Validate(state)
+	return answers, fieldErrors, nil
+}
+
+// feedbackForm returns the form of a screen's submit_feedback actions, or
+// nil when it has none. Every such action on a screen adds to one form.
+func feedbackForm(schema map[string]interface{}) (*forms.Form, error) {
+	all, err := forms.Parse(schema, forms.ActionSubmitFeedback)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+
+	merged := &forms.Form{Extra: make(map[string]bool)}
+	seen := make(map[string]bool)
+	for _, form := range all {
+		for _, field := range form.Fields {
+			if !seen[field.Key] {
+				seen[field.Key] = true
+				merged.Fields = append(merged.Fields, field)
+			}
+		}
+		for key := range form.Extra {
+			merged.Extra[key] = true
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"dynamic-ui-backend/internal/forms"
+	"dynamic-ui-backend/internal/models"
+)
+
+// FeedbackIntervals are the periods responses can be counted over.
+var FeedbackIntervals = map[string]bool{"hour": true, "day": true, "week": true, "month": true}
+
+// FeedbackSummary aggregates the responses of a screen.
+type FeedbackSummary struct {
+	Screen    string                  `json:"screen"`
+	Version   string                  `json:"version"`
+	Responses int                     `json:"responses"`
+	Interval  string                  `json:"interval"`
+	OverTime  []models.FeedbackBucket `json:"over_time"`
+	Questions []QuestionSummary       `json:"questions"`
+}
+
+// QuestionSummary aggregates the answers to one input. Options are set for
+// survey groups, Average and Histogram for rating bars; text fields only
+// report how many responses answered them.
+type QuestionSummary struct {
+	Key       string         `json:"key"`
+	Type      string         `json:"type"`
+	Multiple  bool           `json:"multiple,omitempty"`
+	Answered  int            `json:"answered"`
+	Options   []OptionResult `json:"options,omitempty"`
+	Average   *float64       `json:"average,omitempty"`
+	Histogram []RatingResult `json:"histogram,omitempty"`
+}
+
+// OptionResult counts one survey option. Share is the fraction of answers
+// to the question that chose it; for multi-selection groups the shares can
+// add up to more than 1.
+type OptionResult struct {
+	Value string  `json:"value"`
+	Text  string  `json:"text,omitempty"`
+	Count int     `json:"count"`
+	Share float64 `json:"share"`
+}
+
+type RatingResult struct {
+	Rating int `json:"rating"`
+	Count  int `json:"count"`
+}
+
+// Summary aggregates the responses matching the filter. Questions come from
+// the screen's published document; key, when set, limits them to one state
+// key. Options that were answered but are no longer in the screen are
+// listed after the current ones.
+func (s *FeedbackService) Summary(filter *models.FeedbackFilter, interval, key string) (*FeedbackSummary, error) {
+	if !FeedbackIntervals[interval] {
+		return nil, fmt.Errorf("invalid interval '%s'", interval)
+	}
+	schema, err := s.uiService.GetScreenSchema(filter.Screen, filter.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	form, err := feedbackForm(schema)
+	if err != nil {
+		return nil, err
+	}
+	if form == nil {
+		return nil, fmt.Errorf("screen '%s' does not accept feedback", filter.Screen)
+	}
+	fields := form.Fields
+	if key != "" {
+		field, ok := form.Field(key)
+		if !ok {
+			return nil, fmt.Errorf("screen '%s' has no feedback input '%s'", filter.Screen, key)
+		}
+		fields = []forms.Field{field}
+	}
+
+	summary := &FeedbackSummary{Screen: filter.Screen, Version: filter.Version, Interval: interval, Questions: make([]QuestionSummary, 0, len(fields))}
+	if summary.OverTime, err = s.repo.CountOverTime(filter, interval); err != nil {
+		return nil, err
+	}
+	for _, b := range summary.OverTime {
+		summary.Responses += b.Count
+	}
+
+	answered, err := s.repo.AnsweredCounts(filter)
+	if err != nil {
+		return nil, err
+	}
+	var surveyKeys, ratingKeys []string
+	for _, field := range fields {
+		switch field.Widget {
+		case "survey_group":
+			surveyKeys = append(surveyKeys, field.Key)
+		case "rating_bar":
+			ratingKeys = append(ratingKeys, field.Key)
+		}
+	}
+	options, err := s.repo.ValueCounts(filter, surveyKeys)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := s.repo.NumberCounts(filter, ratingKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		q := QuestionSummary{Key: field.Key, Type: field.Widget, Answered: answered[field.Key]}
+		switch field.Widget {
+		case "survey_group":
+			q.Multiple = field.Multiple
+			q.Options = optionResults(field, options, q.Answered)
+		case "rating_bar":
+			q.Average, q.Histogram = ratingResults(field, ratings)
+		}
+		summary.Questions = append(summary.Questions, q)
+	}
+	return summary, nil
+}
+
+func optionResults(field forms.Field, counts []models.FeedbackValueCount, answered int) []OptionResult {
+	byValue := make(map[string]int)
+	for _, c := range counts {
+		if c.Key == field.Key {
+			byValue[c.Value] = c.Count
+		}
+	}
+
+	results := make([]OptionResult, 0, len(byValue))
+	listed := make(map[string]bool)
+	for _, value := range field.Options {
+		if value == "" || listed[value] {
+			continue
+		}
+		listed[value] = true
+		results = append(results, OptionResult{Value: value, Text: field.Labels[value], Count: byValue[value]})
+	}
+	retired := make([]string, 0)
+	for value := range byValue {
+		if !listed[value] {
+			retired = append(retired, value)
+		}
+	}
+	sort.Strings(retired)
+	for _, value := range retired {
+		results = append(results, OptionResult{Value: value, Count: byValue[value]})
+	}
+
+	if answered > 0 {
+		for i := range results {
+			results[i].Share = float64(results[i].Count) / float64(answered)
+		}
+	}
+	return results
+}
+
+// ratingResults returns the average rating and a histogram from 1 to the
+// field's maximum, extended to cover any higher rating still on record.
+func ratingResults(field forms.Field, counts []models.FeedbackValueCount) (*float64, []RatingResult) {
+	maxRating := 5
+	if field.Max != nil {
+		maxRating = int(*field.Max)
+	}
+
+	byRating := make(map[int]int)
+	total, sum := 0, 0
+	for _, c := range counts {
+		if c.Key != field.Key {
+			continue
+		}
+		rating, err := strconv.Atoi(c.Value)
+		if err != nil || rating < 1 {
+			continue
+		}
+		byRating[rating] += c.Count
+		total += c.Count
+		sum += rating * c.Count
+		if rating > maxRating {
+			maxRating = rating
+		}
+	}
+
+	histogram := make([]RatingResult, 0, maxRating)
+	for rating := 1; rating <= maxRating; rating++ {
+		histogram = append(histogram, RatingResult{Rating: rating, Count: byRating[rating]})
+	}
+	if total == 0 {
+		return nil, histogram
+	}
+	average := float64(sum) / float64(total)
+	return &average, histogram
+}
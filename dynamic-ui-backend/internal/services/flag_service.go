@@ -0,0 +1,282 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const flagsCacheKey = "feature_flags"
+
+var flagKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_.]{0,99}$`)
+
+// FlagService evaluates feature flags / remote config values for a client.
+type FlagService struct {
+	repo  *repositories.FlagRepository
+	cache *cache.Cache
+}
+
+func NewFlagService(repo *repositories.FlagRepository) *FlagService {
+	return &FlagService{repo: repo, cache: cache.New(30*time.Second, time.Minute)}
+}
+
+func (s *FlagService) flags() ([]models.FeatureFlag, error) {
+	if cached, found := s.cache.Get(flagsCacheKey); found {
+		return cached.([]models.FeatureFlag), nil
+	}
+	flags, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set(flagsCacheKey, flags, cache.DefaultExpiration)
+	return flags, nil
+}
+
+// Invalidate drops the cached flag set after an admin change.
+func (s *FlagService) Invalidate() {
+	s.cache.Delete(flagsCacheKey)
+}
+
+// Evaluate returns the value of every flag for the given client.
+func (s *FlagService) Evaluate(client models.ClientContext) (map[string]interface{}, error) {
+	flags, err := s.flags()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{}, len(flags))
+	for _, flag := range flags {
+		raw := evaluateFlag(flag, client)
+		var v interface{}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			continue
+		}
+		values[flag.Key] = v
+	}
+	return values, nil
+}
+
+func evaluateFlag(flag models.FeatureFlag, client models.ClientContext) json.RawMessage {
+	if !flag.IsActive {
+		return flag.DefaultValue
+	}
+	for _, rule := range flag.Rules {
+		if rule.Platform != "" && !strings.EqualFold(rule.Platform, client.Platform) {
+			continue
+		}
+		if !InVersionRange(client.AppVersion, rule.MinAppVersion, rule.MaxAppVersion) {
+			continue
+		}
+		if rule.Percentage != nil && !InRollout(flag.Key, client.DeviceID, *rule.Percentage) {
+			continue
+		}
+		return rule.Value
+	}
+	return flag.DefaultValue
+}
+
+// ValidateFlag checks that the key is well-formed and that the default and
+// every rule value match the declared type.
+func ValidateFlag(key, valueType string, defaultValue json.RawMessage, rules []models.FlagRule) error {
+	if !flagKeyPattern.MatchString(key) {
+		return fmt.Errorf("key must be lowercase letters, digits, '_' or '.'")
+	}
+	if err := checkFlagValue(valueType, defaultValue); err != nil {
+		return fmt.Errorf("default_value: %w", err)
+	}
+	for i, rule := range rules {
+		if err := checkFlagValue(valueType, rule.Value); err != nil {
+			return fmt.Errorf("rules[%d].value: %w", i, err)
+		}
+		if rule.Percentage != nil && (*rule.Percentage < 0 || *rule.Percentage > 100) {
+			return fmt.Errorf("rules[%d].percentage must be between 0 and 100", i)
+		}
+		for _, v := range []string{rule.MinAppVersion, rule.MaxAppVersion} {
+			if v != "" && !isVersionString(v) {
+				return fmt.Errorf("rules[%d]: invalid app version '%s'", i, v)
+			}
+		}
+	}
+	return nil
+}
+
+func checkFlagValue(valueType string, raw json.RawMessage) error {
+	if len(raw) == 0 {
+		return fmt.Errorf("value is required")
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	switch valueType {
+	case models.FlagTypeBoolean:
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("expected boolean")
+		}
+	case models.FlagTypeInteger:
+		n, ok := v.(float64)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Errorf("expected integer")
+		}
+	case models.FlagTypeNumber:
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("expected number")
+		}
+	case models.FlagTypeString:
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("expected string")
+		}
+	case models.FlagTypeJSON:
+		// Any JSON value is accepted.
+	default:
+		return fmt.Errorf("unknown value_type '%s'", valueType)
+	}
+	return nil
+}
+
+// ApplyFlags resolves flag references inside a schema document in place:
+//
+//   - a node with "visible_if": {"flag": "key", "equals": value} is removed
+//     unless the flag evaluates to value (true when "equals" is omitted);
+//   - a property written as {"$flag": "key"} is replaced by the flag value,
+//     or by "default" from the same object when the flag is unknown.
+func ApplyFlags(doc interface{}, flags map[string]interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		if key, ok := v["$flag"].(string); ok && len(v) <= 2 {
+			if value, found := flags[key]; found {
+				return value
+			}
+			return v["default"]
+		}
+		for k, child := range v {
+			if k == "visible_if" {
+				continue
+			}
+			if node, ok := child.(map[string]interface{}); ok && !flagVisible(node, flags) {
+				delete(v, k)
+				continue
+			}
+			v[k] = ApplyFlags(child, flags)
+		}
+		return v
+
+	case []interface{}:
+		kept := make([]interface{}, 0, len(v))
+		for _, child := range v {
+			if node, ok := child.(map[string]interface{}); ok && !flagVisible(node, flags) {
+				continue
+			}
+			kept = append(kept, ApplyFlags(child, flags))
+		}
+		return kept
+	}
+	return doc
+}
+
+func flagVisible(node map[string]interface{}, flags map[string]interface{}) bool {
+	cond, ok := node["visible_if"].(map[string]interface{})
+	if !ok {
+		return true
+	}
+	key, _ := cond["flag"].(string)
+	if key == "" {
+		return true
+	}
+	expected, hasExpected := cond["equals"]
+	if !hasExpected {
+		expected = true
+	}
+	value, found := flags[key]
+	if !found {
+		return false
+	}
+	return fmt.Sprint(value) == fmt.Sprint(expected)
+}
+
+// InRollout deterministically assigns a device to one of 100 buckets per
+// salt and reports whether the bucket falls under percentage. Devices without
+// an ID are only included in full (100%) rollouts.
+func InRollout(salt, deviceID string, percentage int) bool {
+	if percentage >= 100 {
+		return true
+	}
+	if percentage <= 0 || deviceID == "" {
+		return false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(salt + ":" + deviceID))
+	return int(h.Sum32()%100) < percentage
+}
+
+// InVersionRange reports whether version lies within [min, max]; empty bounds
+// are open. An unknown client version only matches unbounded ranges.
+func InVersionRange(version, min, max string) bool {
+	if min == "" && max == "" {
+		return true
+	}
+	if version == "" {
+		return false
+	}
+	if min != "" && CompareVersions(version, min) < 0 {
+		return false
+	}
+	if max != "" && CompareVersions(version, max) > 0 {
+		return false
+	}
+	return true
+}
+
+// CompareVersions compares dotted numeric versions ("1.2.10" > "1.2.9").
+// Missing components count as zero and non-numeric suffixes are ignored.
+func CompareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for len(pa) < len(pb) {
+		pa = append(pa, 0)
+	}
+	for len(pb) < len(pa) {
+		pb = append(pb, 0)
+	}
+	for i := range pa {
+		if pa[i] < pb[i] {
+			return -1
+		}
+		if pa[i] > pb[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+ "); i >= 0 {
+		v = v[:i]
+	}
+	parts := make([]int, 0, 3)
+	for _, p := range strings.Split(v, ".") {
+		n, _ := strconv.Atoi(p)
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+func isVersionString(v string) bool {
+	v = strings.TrimPrefix(v, "v")
+	for _, p := range strings.Split(v, ".") {
+		if _, err := strconv.Atoi(p); err != nil {
+			return false
+		}
+	}
+	return true
+}
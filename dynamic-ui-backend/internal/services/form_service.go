@@ -0,0 +1,64 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"dynamic-ui-backend/internal/forms"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+)
+
+// FormService validates and stores submissions of server-driven forms. The
+// form definitions come from the screen schema; see the forms package.
+type FormService struct {
+	repo *repositories.FormRepository
+}
+
+func NewFormService(repo *repositories.FormRepository) *FormService {
+	return &FormService{repo: repo}
+}
+
+// FormOf returns the form a screen's submit_form actions declare under the
+// ID, or nil when the screen has no such form.
+func FormOf(schema map[string]interface{}, formID string) (*forms.Form, error) {
+	all, err := forms.Parse(schema, forms.ActionSubmitForm)
+	if err != nil {
+		return nil, err
+	}
+	return all[formID], nil
+}
+
+// Submit validates the values against the form and stores them. Invalid
+// submissions return field errors and are not stored.
+func (s *FormService) Submit(req *models.SubmitFormRequest, form *forms.Form, schema map[string]interface{}, revision string, client models.ClientContext, userID *int) (*models.FormSubmission, []forms.FieldError, error) {
+	values, fieldErrors := form.Validate(req.Values)
+	if len(fieldErrors) > 0 {
+		return nil, fieldErrors, nil
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, nil, err
+	}
+	screenID, _ := schema["screen_id"].(string)
+	schemaVersion, _ := schema["version"].(string)
+
+	submission, err := s.repo.Create(&models.FormSubmission{
+		FormID:        form.ID,
+		Screen:        req.Screen,
+		ScreenID:      screenID,
+		Version:       req.Version,
+		SchemaVersion: schemaVersion,
+		Revision:      revision,
+		Data:          data,
+		DeviceID:      client.DeviceID,
+		Platform:      client.Platform,
+		AppVersion:    client.AppVersion,
+		UserID:        userID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to store submission: %w", err)
+	}
+	return submission, nil, nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const killSwitchesCacheKey = "widget_kill_switches"
+
+// KillSwitchService removes crashing widgets from schema responses. The
+// cache is short-lived so a new switch reaches every instance within seconds.
+// When the switches cannot be loaded, the last set that could is used.
+type KillSwitchService struct {
+	repo  *repositories.KillSwitchRepository
+	cache *cache.Cache
+
+	mu        sync.Mutex
+	lastKnown []models.KillSwitch
+}
+
+func NewKillSwitchService(repo *repositories.KillSwitchRepository) *KillSwitchService {
+	return &KillSwitchService{repo: repo, cache: cache.New(5*time.Second, time.Minute)}
+}
+
+func (s *KillSwitchService) active() ([]models.KillSwitch, error) {
+	if cached, found := s.cache.Get(killSwitchesCacheKey); found {
+		return cached.([]models.KillSwitch), nil
+	}
+	all, err := s.repo.GetAll()
+	if err != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lastKnown, fmt.Errorf("failed to load kill switches: %w", err)
+	}
+	active := make([]models.KillSwitch, 0, len(all))
+	for _, ks := range all {
+		if ks.IsActive {
+			active = append(active, ks)
+		}
+	}
+	s.cache.Set(killSwitchesCacheKey, active, cache.DefaultExpiration)
+	s.mu.Lock()
+	s.lastKnown = active
+	s.mu.Unlock()
+	return active, nil
+}
+
+// Invalidate drops the cached switches after an admin change.
+func (s *KillSwitchService) Invalidate() {
+	s.cache.Delete(killSwitchesCacheKey)
+}
+
+// ForClient returns the active switches that apply to the client. If they
+// cannot be loaded, the error is returned along with the switches from the
+// last successful load, which should still be applied.
+func (s *KillSwitchService) ForClient(client models.ClientContext) ([]models.KillSwitch, error) {
+	active, err := s.active()
+	if active == nil {
+		return nil, err
+	}
+	matching := make([]models.KillSwitch, 0)
+	for _, ks := range active {
+		if ks.Platform != "" && !strings.EqualFold(ks.Platform, client.Platform) {
+			continue
+		}
+		if !InVersionRange(client.AppVersion, ks.MinAppVersion, ks.MaxAppVersion) {
+			continue
+		}
+		matching = append(matching, ks)
+	}
+	return matching, err
+}
+
+// ValidateKillSwitch checks a switch definition before it is stored.
+func ValidateKillSwitch(widgetType, widgetID, minAppVersion, maxAppVersion string) error {
+	if widgetType == "" && widgetID == "" {
+		return fmt.Errorf("widget_type or widget_id is required")
+	}
+	for _, v := range []string{minAppVersion, maxAppVersion} {
+		if v != "" && !isVersionString(v) {
+			return fmt.Errorf("invalid app version '%s'", v)
+		}
+	}
+	if minAppVersion != "" && maxAppVersion != "" && CompareVersions(minAppVersion, maxAppVersion) > 0 {
+		return fmt.Errorf("min_app_version is greater than max_app_version")
+	}
+	return nil
+}
+
+// ApplyKillSwitches removes every widget matched by a switch from the
+// document in place, along with its subtree.
+func ApplyKillSwitches(doc map[string]interface{}, switches []models.KillSwitch) {
+	if len(switches) == 0 {
+		return
+	}
+	RemoveWidgets(doc, func(node map[string]interface{}) bool {
+		return killed(node, switches)
+	})
+}
+
+func killed(node map[string]interface{}, switches []models.KillSwitch) bool {
+	typ, _ := node["type"].(string)
+	id, _ := node["id"].(string)
+	for _, ks := range switches {
+		if ks.WidgetType != "" && ks.WidgetType != typ {
+			continue
+		}
+		if ks.WidgetID != "" && ks.WidgetID != id {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"dynamic-ui-backend/internal/models"
+
+	"github.com/patrickmn/go-cache"
+)
+
+// Platform overrides are published documents of kind "overrides" named
+// "<screen>.<platform>" or "<screen>.<platform>.<form_factor>", e.g.
+// overrides/home.ios.json and overrides/home.android.tablet.json.
+//
+// An override looks like a partial screen:
+//
+//	{
+//	  "app_bar": {"title": "Home"},
+//	  "widgets": [
+//	    {"id": "search_section", "padding": {"all": 12}},
+//	    {"id": "ads_banner", "$remove": true}
+//	  ]
+//	}
+//
+// Top-level keys other than "widgets" are deep-merged onto the screen. Each
+// entry of "widgets" is deep-merged onto the screen's widget with the same
+// id, wherever it sits in the tree, or removes it when "$remove" is true.
+// Objects merge key by key, a null value deletes the key, and any other
+// value (lists included) replaces the base value.
+const OverridesKind = "overrides"
+
+// overrideNames returns the overrides that apply to a client, in the order
+// they are merged: the platform override, then the form factor one.
+func overrideNames(screen string, client models.ClientContext) []string {
+	if client.Platform == "" {
+		return nil
+	}
+	names := []string{screen + "." + client.Platform}
+	if client.FormFactor != "" {
+		names = append(names, screen+"."+client.Platform+"."+client.FormFactor)
+	}
+	return names
+}
+
+// OverrideScreen returns the screen an override name belongs to.
+func OverrideScreen(name string) string {
+	screen, _, _ := strings.Cut(name, ".")
+	return screen
+}
+
+// publishedOverrides returns the decoded overrides of a version by name.
+func (s *UIService) publishedOverrides(version string) (map[string]map[string]interface{}, error) {
+	cacheKey := "overrides_" + version
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(map[string]map[string]interface{}), nil
+	}
+
+	files, err := s.PublishedFiles(version)
+	if err != nil {
+		return nil, err
+	}
+	overrides := make(map[string]map[string]interface{})
+	for _, f := range files {
+		kind, name, err := ParseSchemaPath(f.Path)
+		if err != nil || kind != OverridesKind {
+			continue
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(f.Data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid override '%s': %w", f.Path, err)
+		}
+		overrides[name] = doc
+	}
+
+	s.cache.Set(cacheKey, overrides, cache.DefaultExpiration)
+	return overrides, nil
+}
+
+// ApplyOverrides returns the schema with the client's platform overrides
+// merged in, and whether any override applied. The base is never modified;
+// when no override applies it is returned as is.
+func (s *UIService) ApplyOverrides(schema map[string]interface{}, version, screen string, client models.ClientContext) (map[string]interface{}, bool, error) {
+	names := overrideNames(screen, client)
+	if len(names) == 0 {
+		return schema, false, nil
+	}
+	overrides, err := s.publishedOverrides(version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	merged, applied := schema, false
+	for _, name := range names {
+		override, ok := overrides[name]
+		if !ok {
+			continue
+		}
+		if !applied {
+			merged = CloneDocument(schema).(map[string]interface{})
+			applied = true
+		}
+		MergeOverride(merged, override)
+	}
+	return merged, applied, nil
+}
+
+// GetScreenSchemaFor returns the screen with the client's platform and form
+// factor overrides applied. Merged results are cached per platform and form
+// factor; clients with no matching override share the base schema.
+func (s *UIService) GetScreenSchemaFor(screenName, version string, client models.ClientContext) (map[string]interface{}, error) {
+	base, err := s.GetScreenSchema(screenName, version)
+	if err != nil {
+		return nil, err
+	}
+	if client.Platform == "" {
+		return base, nil
+	}
+
+	// Form factors without an override of their own merge exactly like the
+	// bare platform, so they share its cache entry.
+	if client.FormFactor != "" {
+		overrides, err := s.publishedOverrides(version)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := overrides[screenName+"."+client.Platform+"."+client.FormFactor]; !ok {
+			client.FormFactor = ""
+		}
+	}
+
+	cacheKey := fmt.Sprintf("schema_%s_%s_%s_%s", screenName, version, client.Platform, client.FormFactor)
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(map[string]interface{}), nil
+	}
+
+	merged, applied, err := s.ApplyOverrides(base, version, screenName, client)
+	if err != nil {
+		return nil, err
+	}
+	// Only real merges are cached, so arbitrary platform headers cannot grow
+	// the cache; everyone else reads the base entry.
+	if applied {
+		s.cache.Set(cacheKey, merged, cache.DefaultExpiration)
+	}
+	return merged, nil
+}
+
+// MergeOverride merges an override document onto a screen in place.
+func MergeOverride(screen, override map[string]interface{}) {
+	for k, v := range override {
+		if k == "widgets" {
+			continue
+		}
+		mergeValue(screen, k, v)
+	}
+
+	widgets, _ := override["widgets"].([]interface{})
+	if len(widgets) == 0 {
+		return
+	}
+
+	index := widgetsByID(screen)
+	removed := make(map[string]bool)
+	for _, w := range widgets {
+		patch, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := patch["id"].(string)
+		node, found := index[id]
+		if !found {
+			continue
+		}
+		if remove, _ := patch["$remove"].(bool); remove {
+			removed[id] = true
+			continue
+		}
+		for k, v := range patch {
+			if k == "id" {
+				continue
+			}
+			mergeValue(node, k, v)
+		}
+	}
+
+	if len(removed) > 0 {
+		RemoveWidgets(screen, func(node map[string]interface{}) bool {
+			id, _ := node["id"].(string)
+			return removed[id]
+		})
+	}
+}
+
+func mergeValue(dst map[string]interface{}, key string, value interface{}) {
+	if value == nil {
+		delete(dst, key)
+		return
+	}
+	patch, isMap := value.(map[string]interface{})
+	current, hasMap := dst[key].(map[string]interface{})
+	if isMap && hasMap {
+		for k, v := range patch {
+			mergeValue(current, k, v)
+		}
+		return
+	}
+	dst[key] = CloneDocument(value)
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	SchemaEventPublished  = "published"
+	SchemaEventRolledBack = "rolled_back"
+	SchemaEventCanary     = "canary"
+
+	schemaEventHistory = 256
+)
+
+type SchemaEvent struct {
+	ID      int64     `json:"id"`
+	Type    string    `json:"type"`
+	Version string    `json:"version"`
+	Path    string    `json:"path"`
+	Screen  string    `json:"screen,omitempty"`
+	Hash    string    `json:"hash"`
+	At      time.Time `json:"at"`
+}
+
+// SchemaEvents fans schema change notifications out to streaming clients and
+// keeps a short history so reconnecting clients can resume. Events are local
+// to this process.
+type SchemaEvents struct {
+	mu          sync.Mutex
+	baseID      int64
+	nextID      int64
+	history     []SchemaEvent
+	subscribers map[chan SchemaEvent]struct{}
+}
+
+func NewSchemaEvents() *SchemaEvents {
+	// Seed IDs from the clock so IDs handed out before a restart can be
+	// recognised as stale.
+	base := time.Now().UnixMilli()
+	return &SchemaEvents{
+		baseID:      base,
+		nextID:      base,
+		subscribers: make(map[chan SchemaEvent]struct{}),
+	}
+}
+
+func (e *SchemaEvents) Publish(event SchemaEvent) SchemaEvent {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.nextID++
+	event.ID = e.nextID
+	event.At = time.Now().UTC()
+
+	e.history = append(e.history, event)
+	if len(e.history) > schemaEventHistory {
+		e.history = e.history[len(e.history)-schemaEventHistory:]
+	}
+
+	for ch := range e.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Slow consumer: drop it, the client resumes via Last-Event-ID.
+			delete(e.subscribers, ch)
+			close(ch)
+		}
+	}
+	return event
+}
+
+// Subscribe registers a new listener. If lastID is non-zero the events after
+// it are returned for replay; ok is false when lastID is older than the
+// retained history and the client must refetch everything.
+func (e *SchemaEvents) Subscribe(lastID int64) (ch chan SchemaEvent, replay []SchemaEvent, ok bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ch = make(chan SchemaEvent, 16)
+	e.subscribers[ch] = struct{}{}
+
+	if lastID == 0 {
+		return ch, nil, true
+	}
+	if lastID < e.baseID || lastID > e.nextID {
+		return ch, nil, false
+	}
+	if len(e.history) > 0 && lastID < e.history[0].ID-1 {
+		return ch, nil, false
+	}
+	for _, event := range e.history {
+		if event.ID > lastID {
+			replay = append(replay, event)
+		}
+	}
+	return ch, replay, true
+}
+
+func (e *SchemaEvents) Unsubscribe(ch chan SchemaEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, found := e.subscribers[ch]; found {
+		delete(e.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/patrickmn/go-cache"
+)
+
+// Proof modes for SUBMISSION_PROOF.
+const (
+	ProofNone        = ""
+	ProofWork        = "pow"
+	ProofAttestation = "attestation"
+	ProofAny         = "any"
+)
+
+const (
+	blockedSubmittersCacheKey = "blocked_submitters"
+
+	quotaWindow      = time.Hour
+	duplicateWindow  = 24 * time.Hour
+	autoBlockFor     = 24 * time.Hour
+	powMaxClockSkew  = 10 * time.Minute
+	defaultPowBits   = 20
+	defaultDeviceCap = 20
+	defaultIPCap     = 100
+)
+
+// Submitter identifies who is posting to a public submission endpoint.
+type Submitter struct {
+	DeviceID string
+	IP       string
+}
+
+// SubmissionProof carries the optional proof headers of a submission.
+type SubmissionProof struct {
+	// Work is "<unix seconds>:<nonce>" from X-Proof-Of-Work.
+	Work string
+	// Attestation is the token from X-App-Attestation.
+	Attestation string
+}
+
+// Rejection is why a submission was refused, with the HTTP status and the
+// error code to report.
+type Rejection struct {
+	Status     int
+	Code       string
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (r *Rejection) Error() string {
+	return r.Message
+}
+
+// SubmissionGuard protects the public submission endpoints from spam: it
+// refuses blocked devices and IPs, enforces hourly quotas per device and per
+// IP, rejects a device resubmitting an identical payload and, when
+// configured, requires a proof of work or an app attestation token.
+// Submitters that keep going at twice their quota are blocked for a day.
+//
+// Configuration:
+//
+//	SUBMISSION_DEVICE_QUOTA     submissions per device per hour (default 20)
+//	SUBMISSION_IP_QUOTA         submissions per IP per hour (default 100)
+//	SUBMISSION_PROOF            "", "pow", "attestation" or "any" (either one)
+//	SUBMISSION_POW_BITS         leading zero bits a proof of work needs (default 20)
+//	SUBMISSION_ATTESTATION_KEY  base64 Ed25519 public key that signs attestation tokens
+//
+// A proof of work is a nonce such that sha256("<device>:<body hash>:<unix
+// seconds>:<nonce>") starts with the required number of zero bits, where the
+// body hash is the hex SHA-256 of the request body. Attestation tokens are
+// EdDSA-signed JWTs whose subject is the device ID, issued by the service
+// that verifies the platform attestation.
+type SubmissionGuard struct {
+	repo           *repositories.BlockedSubmitterRepository
+	counters       *cache.Cache
+	blocked        *cache.Cache
+	deviceQuota    int
+	ipQuota        int
+	proof          string
+	powBits        int
+	attestationKey ed25519.PublicKey
+}
+
+func NewSubmissionGuard(repo *repositories.BlockedSubmitterRepository) (*SubmissionGuard, error) {
+	g := &SubmissionGuard{
+		repo:        repo,
+		counters:    cache.New(quotaWindow, 10*time.Minute),
+		blocked:     cache.New(30*time.Second, time.Minute),
+		deviceQuota: envInt("SUBMISSION_DEVICE_QUOTA", defaultDeviceCap),
+		ipQuota:     envInt("SUBMISSION_IP_QUOTA", defaultIPCap),
+		proof:       os.Getenv("SUBMISSION_PROOF"),
+		powBits:     envInt("SUBMISSION_POW_BITS", defaultPowBits),
+	}
+
+	switch g.proof {
+	case ProofNone, ProofWork, ProofAttestation, ProofAny:
+	default:
+		return nil, fmt.Errorf("invalid SUBMISSION_PROOF '%s'", g.proof)
+	}
+	if raw := os.Getenv("SUBMISSION_ATTESTATION_KEY"); raw != "" {
+		key, err := base64.StdEncoding.DecodeString(raw)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("SUBMISSION_ATTESTATION_KEY must be a base64 Ed25519 public key")
+		}
+		g.attestationKey = key
+	}
+	if (g.proof == ProofAttestation || g.proof == ProofAny) && g.attestationKey == nil {
+		return nil, fmt.Errorf("SUBMISSION_PROOF '%s' needs SUBMISSION_ATTESTATION_KEY", g.proof)
+	}
+	return g, nil
+}
+
+func envInt(key string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return fallback
+}
+
+// PayloadHash returns the hash duplicate detection compares. It is taken
+// over the decoded request re-encoded as JSON, so formatting and key order
+// do not make a resubmission look new.
+func PayloadHash(kind string, payload interface{}) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(kind+"\n"), data...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Admit decides whether a submission may be processed. It counts against
+// the submitter's quotas whether or not it is later accepted. body is the
+// raw request body, which proofs of work are bound to.
+func (g *SubmissionGuard) Admit(who Submitter, body []byte, payloadHash string, proof SubmissionProof) *Rejection {
+	if g.blockedEntry(who) != nil {
+		return &Rejection{Status: http.StatusForbidden, Code: "SUBMITTER_BLOCKED", Message: "Submissions from this device or network are blocked"}
+	}
+	if rejection := g.checkProof(who, body, proof); rejection != nil {
+		return rejection
+	}
+	if rejection := g.countQuota(models.SubmitterDevice, who.DeviceID, g.deviceQuota); rejection != nil {
+		return rejection
+	}
+	if rejection := g.countQuota(models.SubmitterIP, who.IP, g.ipQuota); rejection != nil {
+		return rejection
+	}
+	if _, dup := g.counters.Get(duplicateKey(who.DeviceID, payloadHash)); dup {
+		return &Rejection{Status: http.StatusConflict, Code: "DUPLICATE_SUBMISSION", Message: "This submission was already received"}
+	}
+	return nil
+}
+
+// Accepted records a stored submission so the device cannot send the same
+// payload again for a day.
+func (g *SubmissionGuard) Accepted(who Submitter, payloadHash string) {
+	g.counters.Set(duplicateKey(who.DeviceID, payloadHash), true, duplicateWindow)
+}
+
+func duplicateKey(deviceID, payloadHash string) string {
+	return "dup:" + deviceID + ":" + payloadHash
+}
+
+// countQuota counts a submission in the submitter's hourly window. Going
+// past twice the quota blocks the submitter for a day.
+func (g *SubmissionGuard) countQuota(kind, value string, quota int) *Rejection {
+	if value == "" {
+		return nil
+	}
+	key := "quota:" + kind + ":" + value
+	g.counters.Add(key, 0, quotaWindow)
+	count, err := g.counters.IncrementInt(key, 1)
+	if err != nil || count <= quota {
+		return nil
+	}
+
+	if count == 2*quota+1 {
+		expires := time.Now().Add(autoBlockFor)
+		reason := fmt.Sprintf("more than %d submissions within an hour", 2*quota)
+		if _, err := g.repo.Block(kind, value, reason, true, &expires, nil); err == nil {
+			g.blocked.Delete(blockedSubmittersCacheKey)
+		}
+	}
+	_, expiresAt, _ := g.counters.GetWithExpiration(key)
+	return &Rejection{Status: http.StatusTooManyRequests, Code: "RATE_LIMITED", Message: "Too many submissions, try again later",
+		RetryAfter: time.Until(expiresAt)}
+}
+
+// blockedEntry returns the active block matching the submitter. Lookup
+// failures let the submission through; quotas still apply.
+func (g *SubmissionGuard) blockedEntry(who Submitter) *models.BlockedSubmitter {
+	var entries map[string]models.BlockedSubmitter
+	if cached, found := g.blocked.Get(blockedSubmittersCacheKey); found {
+		entries = cached.(map[string]models.BlockedSubmitter)
+	} else {
+		list, err := g.repo.GetAll(false)
+		if err != nil {
+			return nil
+		}
+		entries = make(map[string]models.BlockedSubmitter, len(list))
+		for _, b := range list {
+			entries[b.Kind+":"+b.Value] = b
+		}
+		g.blocked.Set(blockedSubmittersCacheKey, entries, cache.DefaultExpiration)
+	}
+
+	for _, key := range []string{models.SubmitterDevice + ":" + who.DeviceID, models.SubmitterIP + ":" + who.IP} {
+		if b, ok := entries[key]; ok && (b.ExpiresAt == nil || b.ExpiresAt.After(time.Now())) {
+			return &b
+		}
+	}
+	return nil
+}
+
+// InvalidateBlocked drops the cached block list after an admin change.
+func (g *SubmissionGuard) InvalidateBlocked() {
+	g.blocked.Delete(blockedSubmittersCacheKey)
+}
+
+func (g *SubmissionGuard) checkProof(who Submitter, body []byte, proof SubmissionProof) *Rejection {
+	if g.proof == ProofNone {
+		return nil
+	}
+	if g.proof != ProofWork && proof.Attestation != "" {
+		if err := g.verifyAttestation(who.DeviceID, proof.Attestation); err == nil {
+			return nil
+		} else if g.proof == ProofAttestation || proof.Work == "" {
+			return &Rejection{Status: http.StatusForbidden, Code: "INVALID_PROOF", Message: err.Error()}
+		}
+	}
+	if g.proof != ProofAttestation && proof.Work != "" {
+		if err := g.verifyWork(who.DeviceID, body, proof.Work); err != nil {
+			return &Rejection{Status: http.StatusForbidden, Code: "INVALID_PROOF", Message: err.Error()}
+		}
+		return nil
+	}
+
+	message := fmt.Sprintf("Proof of work with %d zero bits required", g.powBits)
+	switch g.proof {
+	case ProofAttestation:
+		message = "App attestation required"
+	case ProofAny:
+		message = fmt.Sprintf("Proof of work with %d zero bits or app attestation required", g.powBits)
+	}
+	return &Rejection{Status: http.StatusForbidden, Code: "PROOF_REQUIRED", Message: message}
+}
+
+func (g *SubmissionGuard) verifyWork(deviceID string, body []byte, work string) error {
+	stamp, nonce, ok := strings.Cut(work, ":")
+	seconds, err := strconv.ParseInt(stamp, 10, 64)
+	if !ok || err != nil || nonce == "" {
+		return fmt.Errorf("malformed proof of work")
+	}
+	if skew := time.Since(time.Unix(seconds, 0)); skew > powMaxClockSkew || skew < -powMaxClockSkew {
+		return fmt.Errorf("proof of work has expired")
+	}
+
+	bodyHash := sha256.Sum256(body)
+	sum := sha256.Sum256([]byte(deviceID + ":" + hex.EncodeToString(bodyHash[:]) + ":" + stamp + ":" + nonce))
+	if leadingZeroBits(sum[:]) < g.powBits {
+		return fmt.Errorf("proof of work is too weak")
+	}
+	return nil
+}
+
+func leadingZeroBits(b []byte) int {
+	n := 0
+	for _, c := range b {
+		if c != 0 {
+			return n + bits.LeadingZeros8(c)
+		}
+		n += 8
+	}
+	return n
+}
+
+func (g *SubmissionGuard) verifyAttestation(deviceID, token string) error {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return g.attestationKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return fmt.Errorf("invalid attestation token")
+	}
+	if claims.Subject != deviceID {
+		return fmt.Errorf("attestation token was issued to another device")
+	}
+	return nil
+}
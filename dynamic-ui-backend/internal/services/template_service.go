@@ -0,0 +1,299 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/validation"
+)
+
+// TemplatesKind holds screen templates: screen documents with {{param}}
+// placeholders and a "template" block describing the parameters:
+//
+//	"template": {
+//	  "description": "Campaign landing screen",
+//	  "params": {
+//	    "title": {"description": "Headline", "required": true},
+//	    "accent_color": {"default": "#F4D589"}
+//	  }
+//	}
+//
+// A string that is exactly "{{param}}" takes the parameter's value as is
+// (so numbers and objects can be passed); placeholders inside longer strings
+// are replaced with the value's text. {{screen}} and {{version}} are always
+// available.
+const TemplatesKind = "templates"
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z][a-z0-9_]*)\s*\}\}`)
+
+type TemplateParam struct {
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+}
+
+type TemplateInfo struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	Params      map[string]TemplateParam `json:"params"`
+}
+
+// CreatedScreen reports the draft written for a new screen. When the result
+// has validation errors nothing is written and Created is false.
+type CreatedScreen struct {
+	Version string             `json:"version"`
+	Path    string             `json:"path"`
+	Created bool               `json:"created"`
+	Issues  []validation.Issue `json:"issues"`
+}
+
+// TemplateService creates new draft screens from templates or by cloning a
+// published screen.
+type TemplateService struct {
+	uiService         *UIService
+	validationService *ValidationService
+}
+
+func NewTemplateService(uiService *UIService, validationService *ValidationService) *TemplateService {
+	return &TemplateService{uiService: uiService, validationService: validationService}
+}
+
+type templateDoc struct {
+	Template struct {
+		Description string                   `json:"description"`
+		Params      map[string]TemplateParam `json:"params"`
+	} `json:"template"`
+}
+
+// List describes the published templates of a version.
+func (s *TemplateService) List(version string) ([]TemplateInfo, error) {
+	files, err := s.uiService.PublishedFiles(version)
+	if err != nil {
+		return nil, err
+	}
+	templates := make([]TemplateInfo, 0)
+	for _, f := range files {
+		kind, name, err := ParseSchemaPath(f.Path)
+		if err != nil || kind != TemplatesKind {
+			continue
+		}
+		var doc templateDoc
+		if err := json.Unmarshal(f.Data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid template '%s': %w", name, err)
+		}
+		if doc.Template.Params == nil {
+			doc.Template.Params = map[string]TemplateParam{}
+		}
+		templates = append(templates, TemplateInfo{Name: name, Description: doc.Template.Description, Params: doc.Template.Params})
+	}
+	return templates, nil
+}
+
+// Instantiate fills a template's placeholders and stores the result as a
+// draft of the new screen.
+func (s *TemplateService) Instantiate(req *models.InstantiateTemplateRequest) (*CreatedScreen, error) {
+	if req.Version == "" {
+		req.Version = "v1"
+	}
+	if req.TargetVersion == "" {
+		req.TargetVersion = req.Version
+	}
+	if !IsValidName(req.Template) {
+		return nil, fmt.Errorf("invalid template name")
+	}
+
+	data, err := s.uiService.ReadPublished(req.Version, TemplatesKind+"/"+req.Template+".json")
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("template '%s' not found for version '%s'", req.Template, req.Version)
+	}
+
+	var meta templateDoc
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("invalid template '%s': %w", req.Template, err)
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid template '%s': %w", req.Template, err)
+	}
+	delete(doc, "template")
+
+	values, err := templateValues(meta.Template.Params, req.Params)
+	if err != nil {
+		return nil, err
+	}
+	values["screen"] = req.Screen
+	values["version"] = req.TargetVersion
+
+	unresolved := make(map[string]bool)
+	filled, _ := fillPlaceholders(doc, values, unresolved).(map[string]interface{})
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf("template uses undeclared parameters: %s", strings.Join(SortedKeys(unresolved), ", "))
+	}
+
+	return s.createDraft(filled, req.Template, req.Screen, req.TargetVersion, req.Overwrite)
+}
+
+// Clone copies a published screen into a new draft screen.
+func (s *TemplateService) Clone(req *models.CloneScreenRequest) (*CreatedScreen, error) {
+	if req.SourceVersion == "" {
+		req.SourceVersion = "v1"
+	}
+	if req.Version == "" {
+		req.Version = req.SourceVersion
+	}
+	if !IsValidName(req.Source) {
+		return nil, fmt.Errorf("invalid source screen")
+	}
+
+	data, err := s.uiService.ReadPublished(req.SourceVersion, "screens/"+req.Source+".json")
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("schema '%s' not found for version '%s'", req.Source, req.SourceVersion)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid schema '%s': %w", req.Source, err)
+	}
+	return s.createDraft(doc, req.Source, req.Screen, req.Version, req.Overwrite)
+}
+
+// createDraft gives the document the new screen's identity, validates it and
+// writes it as a draft.
+func (s *TemplateService) createDraft(doc map[string]interface{}, origin, screen, version string, overwrite bool) (*CreatedScreen, error) {
+	if !IsValidName(screen) || !IsValidName(version) {
+		return nil, fmt.Errorf("invalid screen '%s' or version '%s'", screen, version)
+	}
+	path := "screens/" + screen + ".json"
+
+	if !overwrite {
+		for _, read := range []func(string, string) ([]byte, error){s.uiService.ReadPublished, s.uiService.ReadDraft} {
+			existing, err := read(version, path)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				return nil, fmt.Errorf("screen '%s' already exists in version '%s'; set overwrite to replace it", screen, version)
+			}
+		}
+	}
+
+	doc["screen_id"] = screen + "_" + version
+	RegenerateWidgetIDs(doc, origin, screen)
+
+	issues, err := s.validationService.Validate(doc)
+	if err != nil {
+		return nil, err
+	}
+	result := &CreatedScreen{Version: version, Path: path, Issues: issues}
+	if validation.HasErrors(issues) {
+		return result, nil
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.uiService.WriteDraft(version, path, data); err != nil {
+		return nil, err
+	}
+	result.Created = true
+	return result, nil
+}
+
+func templateValues(params map[string]TemplateParam, supplied map[string]interface{}) (map[string]interface{}, error) {
+	for name := range supplied {
+		if _, declared := params[name]; !declared {
+			return nil, fmt.Errorf("unknown template parameter '%s'", name)
+		}
+	}
+
+	values := make(map[string]interface{}, len(params)+2)
+	missing := make([]string, 0)
+	for name, p := range params {
+		switch v, ok := supplied[name]; {
+		case ok:
+			values[name] = v
+		case p.Default != nil:
+			values[name] = p.Default
+		case p.Required:
+			missing = append(missing, name)
+		default:
+			values[name] = ""
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("missing required template parameters: %s", strings.Join(missing, ", "))
+	}
+	return values, nil
+}
+
+// fillPlaceholders replaces {{param}} placeholders throughout a document and
+// records any placeholder without a value in unresolved.
+func fillPlaceholders(v interface{}, values map[string]interface{}, unresolved map[string]bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			t[k] = fillPlaceholders(child, values, unresolved)
+		}
+		return t
+	case []interface{}:
+		for i, child := range t {
+			t[i] = fillPlaceholders(child, values, unresolved)
+		}
+		return t
+	case string:
+		if m := placeholderPattern.FindStringSubmatch(t); m != nil && m[0] == t {
+			if value, ok := values[m[1]]; ok {
+				return CloneDocument(value)
+			}
+			unresolved[m[1]] = true
+			return t
+		}
+		return placeholderPattern.ReplaceAllStringFunc(t, func(match string) string {
+			name := placeholderPattern.FindStringSubmatch(match)[1]
+			value, ok := values[name]
+			if !ok {
+				unresolved[name] = true
+				return match
+			}
+			if s, ok := value.(string); ok {
+				return s
+			}
+			data, _ := json.Marshal(value)
+			return string(data)
+		})
+	}
+	return v
+}
+
+// RegenerateWidgetIDs gives every widget id the new screen's prefix, so ids
+// of a copied screen never collide with the original's kill switches or
+// overrides. An existing prefix of the origin screen is replaced rather
+// than stacked.
+func RegenerateWidgetIDs(doc map[string]interface{}, origin, screen string) {
+	used := make(map[string]bool)
+	EachWidget(doc, func(node map[string]interface{}) {
+		id, _ := node["id"].(string)
+		if id == "" {
+			return
+		}
+		base := strings.TrimPrefix(id, origin+"_")
+		newID := screen + "_" + base
+		for n := 2; used[newID]; n++ {
+			newID = fmt.Sprintf("%s_%s_%d", screen, base, n)
+		}
+		used[newID] = true
+		node["id"] = newID
+	})
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+)
+
+// Trash kinds, as used in the admin trash routes.
+const (
+	TrashCategories = "categories"
+	TrashBrands     = "brands"
+)
+
+// ErrImageCleanup is returned, wrapped, when entries were purged but some of
+// their image files could not be removed.
+var ErrImageCleanup = errors.New("failed to remove purged images")
+
+// TrashService restores and purges deleted categories and brands. Purged
+// entries take their uploaded image with them unless another category or
+// brand still uses it.
+type TrashService struct {
+	categoryRepo *repositories.CategoryRepository
+	brandRepo    *repositories.BrandRepository
+	uploadDir    string
+	baseURL      string
+	retention    time.Duration
+}
+
+// NewTrashService reads TRASH_RETENTION_DAYS (default 30), the number of
+// days deleted entries are kept before PurgeExpired removes them.
+func NewTrashService(categoryRepo *repositories.CategoryRepository, brandRepo *repositories.BrandRepository) (*TrashService, error) {
+	days := 30
+	if v := os.Getenv("TRASH_RETENTION_DAYS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("TRASH_RETENTION_DAYS must be a positive number of days, got %q", v)
+		}
+		days = n
+	}
+	uploadDir := os.Getenv("UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = "./uploads"
+	}
+	baseURL := os.Getenv("BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	return &TrashService{
+		categoryRepo: categoryRepo,
+		brandRepo:    brandRepo,
+		uploadDir:    uploadDir,
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		retention:    time.Duration(days) * 24 * time.Hour,
+	}, nil
+}
+
+// Retention is how long deleted entries are kept.
+func (s *TrashService) Retention() time.Duration {
+	return s.retention
+}
+
+// List returns everything in the trash.
+func (s *TrashService) List() (*models.Trash, error) {
+	categories, err := s.categoryRepo.GetDeleted()
+	if err != nil {
+		return nil, err
+	}
+	brands, err := s.brandRepo.GetDeleted()
+	if err != nil {
+		return nil, err
+	}
+	return &models.Trash{
+		Categories:    categories,
+		Brands:        brands,
+		RetentionDays: int(s.retention / (24 * time.Hour)),
+	}, nil
+}
+
+// Restore takes a category or brand out of the trash.
+func (s *TrashService) Restore(kind string, id, userID int) (interface{}, error) {
+	switch kind {
+	case TrashCategories:
+		return s.categoryRepo.Restore(id, userID)
+	case TrashBrands:
+		return s.brandRepo.Restore(id, userID)
+	}
+	return nil, fmt.Errorf("unknown trash kind %q", kind)
+}
+
+// Purge permanently removes a category or brand from the trash, before its
+// retention has run out.
+func (s *TrashService) Purge(kind string, id int) error {
+	var imageURL string
+	var err error
+	switch kind {
+	case TrashCategories:
+		imageURL, err = s.categoryRepo.Purge(id)
+	case TrashBrands:
+		imageURL, err = s.brandRepo.Purge(id)
+	default:
+		return fmt.Errorf("unknown trash kind %q", kind)
+	}
+	if err != nil {
+		return err
+	}
+	return s.removeImages([]string{imageURL})
+}
+
+// PurgeExpired permanently removes the entries deleted longer ago than the
+// retention and returns how many were removed.
+func (s *TrashService) PurgeExpired() (int, error) {
+	cutoff := time.Now().Add(-s.retention)
+	categoryImages, err := s.categoryRepo.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		return 0, err
+	}
+	brandImages, err := s.brandRepo.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		return len(categoryImages), err
+	}
+	images := append(categoryImages, brandImages...)
+	return len(images), s.removeImages(images)
+}
+
+// removeImages deletes the uploaded category and brand images that no
+// remaining category or brand refers to. Images outside the categories and
+// brands upload folders may be shared with UI schemas and are left alone.
+func (s *TrashService) removeImages(imageURLs []string) error {
+	var errs []error
+	for _, imageURL := range imageURLs {
+		rel := s.uploadPath(imageURL)
+		if rel == "" {
+			continue
+		}
+		categoryRefs, err := s.categoryRepo.ImageReferences(imageURL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		brandRefs, err := s.brandRepo.ImageReferences(imageURL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if categoryRefs+brandRefs > 0 {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.uploadDir, filepath.FromSlash(rel))); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrImageCleanup, errors.Join(errs...))
+	}
+	return nil
+}
+
+// uploadPath returns the path of an image relative to the upload directory
+// when it is a category or brand upload, or "" otherwise.
+func (s *TrashService) uploadPath(imageURL string) string {
+	rel := ""
+	if strings.HasPrefix(imageURL, s.baseURL+"/uploads/") {
+		rel = strings.TrimPrefix(imageURL, s.baseURL+"/uploads/")
+	} else if strings.HasPrefix(imageURL, "/uploads/") {
+		rel = strings.TrimPrefix(imageURL, "/uploads/")
+	}
+	rel = path.Clean(rel)
+	dir, file := path.Split(rel)
+	if (dir != TrashCategories+"/" && dir != TrashBrands+"/") || file == "" {
+		return ""
+	}
+	return rel
+}
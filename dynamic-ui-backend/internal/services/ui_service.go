@@ -1,18 +1,27 @@
 package services
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/patrickmn/go-cache"
 )
 
 type UIService struct {
-	cache      *cache.Cache
-	schemaPath string
+	cache         *cache.Cache
+	schemaPath    string
+	draftsPath    string
+	revisionsPath string
+	events        *SchemaEvents
 }
 
 func NewUIService() *UIService {
@@ -20,8 +29,27 @@ func NewUIService() *UIService {
 	if schemaPath == "" {
 		schemaPath = "./schemas"
 	}
+	draftsPath := os.Getenv("SCHEMA_DRAFTS_PATH")
+	if draftsPath == "" {
+		draftsPath = "./schemas_drafts"
+	}
+	revisionsPath := os.Getenv("SCHEMA_REVISIONS_PATH")
+	if revisionsPath == "" {
+		revisionsPath = "./schemas_revisions"
+	}
 	c := cache.New(5*time.Minute, 10*time.Minute)
-	return &UIService{cache: c, schemaPath: schemaPath}
+	return &UIService{
+		cache:         c,
+		schemaPath:    schemaPath,
+		draftsPath:    draftsPath,
+		revisionsPath: revisionsPath,
+		events:        NewSchemaEvents(),
+	}
+}
+
+// Events returns the hub notified whenever a document is published or rolled back.
+func (s *UIService) Events() *SchemaEvents {
+	return s.events
 }
 
 func (s *UIService) GetScreenSchema(screenName, version string) (map[string]interface{}, error) {
@@ -30,6 +58,10 @@ func (s *UIService) GetScreenSchema(screenName, version string) (map[string]inte
 		return cached.(map[string]interface{}), nil
 	}
 
+	if !IsValidName(screenName) || !IsValidName(version) {
+		return nil, fmt.Errorf("invalid screen '%s' or version '%s'", screenName, version)
+	}
+
 	filePath := filepath.Join(s.schemaPath, version, fmt.Sprintf("%s.json", screenName))
 
 	data, err := os.ReadFile(filePath)
@@ -69,3 +101,408 @@ func (s *UIService) GetAvailableScreens(version string) ([]string, error) {
 func (s *UIService) ClearCache() {
 	s.cache.Flush()
 }
+
+// Schema documents of a version are grouped by kind. Screens live directly in
+// the version directory, every other kind in a subdirectory of the same name.
+var SchemaKinds = []string{"screens", "fragments", "themes", "translations", OverridesKind, TemplatesKind}
+
+// SchemaFile is a schema document addressed by its kind-relative path,
+// e.g. "screens/home.json" or "themes/dark.json".
+type SchemaFile struct {
+	Path string
+	Data []byte
+}
+
+// IsValidName reports whether s is safe to use as a single path element
+// (screen, version, fragment name, ...).
+func IsValidName(s string) bool {
+	if s == "" || s == "." || s == ".." || strings.HasPrefix(s, ".") {
+		return false
+	}
+	return !strings.ContainsAny(s, "/\\")
+}
+
+// ParseSchemaPath splits "kind/name.json" and validates both parts.
+func ParseSchemaPath(path string) (kind, name string, err error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || filepath.Ext(parts[1]) != ".json" {
+		return "", "", fmt.Errorf("invalid schema path '%s'", path)
+	}
+	kind, name = parts[0], strings.TrimSuffix(parts[1], ".json")
+	known := false
+	for _, k := range SchemaKinds {
+		if k == kind {
+			known = true
+			break
+		}
+	}
+	if !known || !IsValidName(name) {
+		return "", "", fmt.Errorf("invalid schema path '%s'", path)
+	}
+	return kind, name, nil
+}
+
+func (s *UIService) filePath(root, version, path string) (string, error) {
+	if !IsValidName(version) {
+		return "", fmt.Errorf("invalid version '%s'", version)
+	}
+	kind, name, err := ParseSchemaPath(path)
+	if err != nil {
+		return "", err
+	}
+	if kind == "screens" {
+		return filepath.Join(root, version, name+".json"), nil
+	}
+	return filepath.Join(root, version, kind, name+".json"), nil
+}
+
+func (s *UIService) listFiles(root, version string) ([]SchemaFile, error) {
+	if !IsValidName(version) {
+		return nil, fmt.Errorf("invalid version '%s'", version)
+	}
+
+	files := make([]SchemaFile, 0)
+	for _, kind := range SchemaKinds {
+		dir := filepath.Join(root, version)
+		if kind != "screens" {
+			dir = filepath.Join(dir, kind)
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" || !IsValidName(entry.Name()) {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, SchemaFile{Path: kind + "/" + entry.Name(), Data: data})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// PublishedFiles returns every published document of a version, sorted by path.
+func (s *UIService) PublishedFiles(version string) ([]SchemaFile, error) {
+	return s.listFiles(s.schemaPath, version)
+}
+
+// DraftFiles returns every draft document of a version, sorted by path.
+func (s *UIService) DraftFiles(version string) ([]SchemaFile, error) {
+	return s.listFiles(s.draftsPath, version)
+}
+
+// ReadPublished returns the published document at path, or nil if it does not exist.
+func (s *UIService) ReadPublished(version, path string) ([]byte, error) {
+	return s.readFile(s.schemaPath, version, path)
+}
+
+// ReadDraft returns the draft document at path, or nil if it does not exist.
+func (s *UIService) ReadDraft(version, path string) ([]byte, error) {
+	return s.readFile(s.draftsPath, version, path)
+}
+
+func (s *UIService) readFile(root, version, path string) ([]byte, error) {
+	fp, err := s.filePath(root, version, path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(fp)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// WriteDraft stores a document in the drafts area after checking it is valid JSON.
+func (s *UIService) WriteDraft(version, path string, data []byte) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("draft '%s' is not valid JSON", path)
+	}
+	fp, err := s.filePath(s.draftsPath, version, path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		return err
+	}
+	return WriteFileAtomic(fp, data)
+}
+
+// PublishDraft replaces the published document with its draft and removes the draft.
+func (s *UIService) PublishDraft(version, path string) error {
+	data, err := s.ReadDraft(version, path)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return fmt.Errorf("draft '%s' not found for version '%s'", path, version)
+	}
+
+	if err := s.Publish(version, path, data); err != nil {
+		return err
+	}
+
+	return s.DeleteDraft(version, path)
+}
+
+// DeleteDraft removes a draft; a missing draft is not an error.
+func (s *UIService) DeleteDraft(version, path string) error {
+	fp, err := s.filePath(s.draftsPath, version, path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Publish replaces the published document and notifies streaming clients.
+func (s *UIService) Publish(version, path string, data []byte) error {
+	if err := s.writePublished(version, path, data); err != nil {
+		return err
+	}
+	s.notify(SchemaEventPublished, version, path, data)
+	return nil
+}
+
+// writePublished replaces a published document, keeping the previous content
+// as a revision so it can be rolled back to.
+func (s *UIService) writePublished(version, path string, data []byte) error {
+	fp, err := s.filePath(s.schemaPath, version, path)
+	if err != nil {
+		return err
+	}
+	previous, err := s.ReadPublished(version, path)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		if err := s.saveRevision(version, path, previous); err != nil {
+			return fmt.Errorf("failed to keep revision: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		return err
+	}
+	if err := WriteFileAtomic(fp, data); err != nil {
+		return err
+	}
+	s.cache.Flush()
+	return nil
+}
+
+// Revision is a previously published copy of a document.
+type Revision struct {
+	ID        string    `json:"id"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s *UIService) revisionDir(version, path string) (string, error) {
+	if !IsValidName(version) {
+		return "", fmt.Errorf("invalid version '%s'", version)
+	}
+	kind, name, err := ParseSchemaPath(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.revisionsPath, version, kind, name), nil
+}
+
+// RevisionsDir is the directory holding every kept revision of a version.
+func (s *UIService) RevisionsDir(version string) (string, error) {
+	if !IsValidName(version) {
+		return "", fmt.Errorf("invalid version '%s'", version)
+	}
+	return filepath.Join(s.revisionsPath, version), nil
+}
+
+func (s *UIService) saveRevision(version, path string, data []byte) error {
+	dir, err := s.revisionDir(version, path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	id := fmt.Sprintf("%d", time.Now().UnixNano())
+	return WriteFileAtomic(filepath.Join(dir, id+".json"), data)
+}
+
+// ListRevisions returns the kept revisions of a document, newest first.
+func (s *UIService) ListRevisions(version, path string) ([]Revision, error) {
+	dir, err := s.revisionDir(version, path)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Revision{}, nil
+		}
+		return nil, err
+	}
+
+	revisions := make([]Revision, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		id := strings.TrimSuffix(entry.Name(), ".json")
+		nanos, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, Revision{ID: id, Hash: ContentHash(data), CreatedAt: time.Unix(0, nanos).UTC()})
+	}
+
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].ID > revisions[j].ID })
+	return revisions, nil
+}
+
+// Rollback republishes a kept revision (the newest one when revisionID is
+// empty). The content being replaced is itself kept as a revision.
+func (s *UIService) Rollback(version, path, revisionID string) (*Revision, error) {
+	revisions, err := s.ListRevisions(version, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("no revisions of '%s' for version '%s'", path, version)
+	}
+
+	target := revisions[0]
+	if revisionID != "" {
+		found := false
+		for _, rev := range revisions {
+			if rev.ID == revisionID {
+				target, found = rev, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("revision '%s' not found", revisionID)
+		}
+	}
+
+	dir, _ := s.revisionDir(version, path)
+	revisionFile := filepath.Join(dir, target.ID+".json")
+	data, err := os.ReadFile(revisionFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.writePublished(version, path, data); err != nil {
+		return nil, err
+	}
+	// The restored content is live again, so it no longer needs its own revision.
+	if err := os.Remove(revisionFile); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	s.notify(SchemaEventRolledBack, version, path, data)
+	return &target, nil
+}
+
+// ContentHash is the hex SHA-256 of a document's bytes.
+func ContentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *UIService) notify(eventType, version, path string, data []byte) {
+	event := SchemaEvent{Type: eventType, Version: version, Path: path, Hash: ContentHash(data)}
+	if kind, name, err := ParseSchemaPath(path); err == nil {
+		switch kind {
+		case "screens":
+			event.Screen = name
+		case OverridesKind:
+			event.Screen = OverrideScreen(name)
+		}
+	}
+	s.events.Publish(event)
+}
+
+// SameDocument reports whether two JSON documents are equal, ignoring
+// insignificant whitespace.
+func SameDocument(a, b []byte) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+// SortedKeys returns the keys of a map in sorted order.
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteFileAtomic replaces a file via a temp file in the same directory, so
+// a failed write never leaves a truncated document behind. An existing
+// file keeps its permissions.
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if info, err := os.Stat(path); err == nil {
+		if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// CloneDocument deep-copies a decoded JSON document so per-request
+// transformations never touch the cached original.
+func CloneDocument(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			out[k] = CloneDocument(child)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = CloneDocument(child)
+		}
+		return out
+	}
+	return doc
+}
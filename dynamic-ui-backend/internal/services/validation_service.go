@@ -0,0 +1,84 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"dynamic-ui-backend/internal/forms"
+	"dynamic-ui-backend/internal/models"
+	"dynamic-ui-backend/internal/repositories"
+	"dynamic-ui-backend/internal/validation"
+)
+
+// ValidationService runs the schema validation rules, feeding them the
+// registry data they need.
+type ValidationService struct {
+	uiService *UIService
+	assetRepo *repositories.AssetRepository
+}
+
+func NewValidationService(uiService *UIService, assetRepo *repositories.AssetRepository) *ValidationService {
+	return &ValidationService{uiService: uiService, assetRepo: assetRepo}
+}
+
+func (s *ValidationService) validator() (*validation.Validator, error) {
+	lotties, err := s.assetRepo.ActiveNames(models.AssetKindLottie)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load lottie assets: %w", err)
+	}
+	icons, err := s.assetRepo.ActiveNames(models.AssetKindIcon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load icon assets: %w", err)
+	}
+
+	return validation.New(
+		validation.Structure{},
+		validation.AssetReferences{Lotties: lotties, Icons: icons},
+		validation.Contrast{},
+		validation.SemanticLabels{},
+		validation.TapTargets{},
+		forms.Rule{},
+	), nil
+}
+
+// Validate checks a single screen document.
+func (s *ValidationService) Validate(doc map[string]interface{}) ([]validation.Issue, error) {
+	v, err := s.validator()
+	if err != nil {
+		return nil, err
+	}
+	return v.Validate(doc), nil
+}
+
+// ValidateVersion checks every published screen of a version, or only the
+// named one, and returns the issues keyed by screen name.
+func (s *ValidationService) ValidateVersion(version, screen string) (map[string][]validation.Issue, error) {
+	v, err := s.validator()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.uiService.PublishedFiles(version)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]validation.Issue)
+	for _, f := range files {
+		kind, name, err := ParseSchemaPath(f.Path)
+		if err != nil || kind != "screens" || (screen != "" && name != screen) {
+			continue
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(f.Data, &doc); err != nil {
+			results[name] = []validation.Issue{{Path: "", Rule: "json", Severity: validation.SeverityError, Message: err.Error()}}
+			continue
+		}
+		results[name] = v.Validate(doc)
+	}
+
+	if screen != "" && len(results) == 0 {
+		return nil, fmt.Errorf("schema '%s' not found for version '%s'", screen, version)
+	}
+	return results, nil
+}
@@ -0,0 +1,92 @@
+package services
+
+// Widgets sit in a few known positions of a screen document: app_bar,
+// widgets and floating_action_button at the top, children and child below.
+// Other objects that carry a "type" (actions, gradients) are not widgets.
+
+// RemoveWidgets deletes, in place, every widget for which match returns true,
+// together with its subtree.
+func RemoveWidgets(doc map[string]interface{}, match func(node map[string]interface{}) bool) {
+	for _, key := range []string{"app_bar", "floating_action_button"} {
+		removeChild(doc, key, match)
+	}
+	removeChildren(doc, "widgets", match)
+}
+
+func removeChild(parent map[string]interface{}, key string, match func(map[string]interface{}) bool) {
+	node, ok := parent[key].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if match(node) {
+		delete(parent, key)
+		return
+	}
+	removeChildren(node, "children", match)
+	removeChild(node, "child", match)
+}
+
+func removeChildren(parent map[string]interface{}, key string, match func(map[string]interface{}) bool) {
+	list, ok := parent[key].([]interface{})
+	if !ok {
+		return
+	}
+	kept := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		if node, ok := item.(map[string]interface{}); ok {
+			if match(node) {
+				continue
+			}
+			removeChildren(node, "children", match)
+			removeChild(node, "child", match)
+		}
+		kept = append(kept, item)
+	}
+	parent[key] = kept
+}
+
+// EachWidget calls fn for every widget of the document in document order.
+func EachWidget(doc map[string]interface{}, fn func(node map[string]interface{})) {
+	var visit func(node map[string]interface{})
+	visit = func(node map[string]interface{}) {
+		fn(node)
+		if children, ok := node["children"].([]interface{}); ok {
+			for _, c := range children {
+				if child, ok := c.(map[string]interface{}); ok {
+					visit(child)
+				}
+			}
+		}
+		if child, ok := node["child"].(map[string]interface{}); ok {
+			visit(child)
+		}
+	}
+
+	if appBar, ok := doc["app_bar"].(map[string]interface{}); ok {
+		visit(appBar)
+	}
+	if widgets, ok := doc["widgets"].([]interface{}); ok {
+		for _, w := range widgets {
+			if node, ok := w.(map[string]interface{}); ok {
+				visit(node)
+			}
+		}
+	}
+	if fab, ok := doc["floating_action_button"].(map[string]interface{}); ok {
+		visit(fab)
+	}
+}
+
+// widgetsByID indexes every widget of the document that has an id. When ids
+// repeat, the first widget in document order wins.
+func widgetsByID(doc map[string]interface{}) map[string]map[string]interface{} {
+	index := make(map[string]map[string]interface{})
+	EachWidget(doc, func(node map[string]interface{}) {
+		if id, ok := node["id"].(string); ok && id != "" {
+			if _, seen := index[id]; !seen {
+				index[id] = node
+			}
+		}
+	})
+	return index
+}
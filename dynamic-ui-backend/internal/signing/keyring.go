@@ -0,0 +1,208 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const Algorithm = "Ed25519"
+
+// Signature is a detached signature over the canonical JSON of a document.
+type Signature struct {
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Value     string `json:"value"`
+}
+
+// PublicKey is a verification key as published to clients.
+type PublicKey struct {
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	PublicKey string `json:"public_key"`
+	Active    bool   `json:"active"`
+}
+
+// KeyRing holds the key used for signing plus every key clients should still
+// accept. Rotation: add the new key, make it active, and keep the old public
+// key in SCHEMA_VERIFY_KEYS until all cached responses have expired.
+type KeyRing struct {
+	activeID   string
+	activeKey  ed25519.PrivateKey
+	publicKeys map[string]ed25519.PublicKey
+}
+
+// LoadFromEnv reads the key ring from the environment:
+//
+//	SCHEMA_SIGNING_KEYS        kid:base64(seed or private key),... signing-capable keys
+//	SCHEMA_SIGNING_ACTIVE_KEY  kid of the key used to sign
+//	SCHEMA_VERIFY_KEYS         kid:base64(public key),... retired keys still accepted
+//
+// With no signing keys configured the key ring is empty and signing is off.
+func LoadFromEnv() (*KeyRing, error) {
+	ring := &KeyRing{publicKeys: make(map[string]ed25519.PublicKey)}
+
+	private := make(map[string]ed25519.PrivateKey)
+	for kid, raw := range parseKeyList(os.Getenv("SCHEMA_SIGNING_KEYS")) {
+		var key ed25519.PrivateKey
+		switch len(raw) {
+		case ed25519.SeedSize:
+			key = ed25519.NewKeyFromSeed(raw)
+		case ed25519.PrivateKeySize:
+			key = ed25519.PrivateKey(raw)
+		default:
+			return nil, fmt.Errorf("signing key '%s' has invalid length %d", kid, len(raw))
+		}
+		private[kid] = key
+		ring.publicKeys[kid] = key.Public().(ed25519.PublicKey)
+	}
+
+	for kid, raw := range parseKeyList(os.Getenv("SCHEMA_VERIFY_KEYS")) {
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("verification key '%s' has invalid length %d", kid, len(raw))
+		}
+		if _, dup := ring.publicKeys[kid]; dup {
+			return nil, fmt.Errorf("key id '%s' is configured twice", kid)
+		}
+		ring.publicKeys[kid] = ed25519.PublicKey(raw)
+	}
+
+	if len(private) == 0 {
+		return ring, nil
+	}
+
+	active := os.Getenv("SCHEMA_SIGNING_ACTIVE_KEY")
+	if active == "" && len(private) == 1 {
+		for kid := range private {
+			active = kid
+		}
+	}
+	key, ok := private[active]
+	if !ok {
+		return nil, fmt.Errorf("SCHEMA_SIGNING_ACTIVE_KEY '%s' is not one of SCHEMA_SIGNING_KEYS", active)
+	}
+	ring.activeID, ring.activeKey = active, key
+	return ring, nil
+}
+
+func parseKeyList(value string) map[string][]byte {
+	keys := make(map[string][]byte)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		kid, encoded, ok := strings.Cut(entry, ":")
+		if !ok || kid == "" {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			// Keep the entry so the length check reports it.
+			raw = nil
+		}
+		keys[kid] = raw
+	}
+	return keys
+}
+
+// Enabled reports whether a signing key is configured.
+func (k *KeyRing) Enabled() bool {
+	return k != nil && k.activeKey != nil
+}
+
+// Sign returns a detached signature over the canonical JSON of doc.
+func (k *KeyRing) Sign(doc interface{}) (*Signature, error) {
+	if !k.Enabled() {
+		return nil, fmt.Errorf("no signing key configured")
+	}
+	payload, err := Canonicalize(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &Signature{
+		KeyID:     k.activeID,
+		Algorithm: Algorithm,
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(k.activeKey, payload)),
+	}, nil
+}
+
+// Verify checks a signature against any key in the ring.
+func (k *KeyRing) Verify(doc interface{}, sig *Signature) error {
+	pub, ok := k.publicKeys[sig.KeyID]
+	if !ok {
+		return fmt.Errorf("unknown key id '%s'", sig.KeyID)
+	}
+	raw, err := base64.StdEncoding.DecodeString(sig.Value)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	payload, err := Canonicalize(doc)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, payload, raw) {
+		return fmt.Errorf("signature does not match")
+	}
+	return nil
+}
+
+// PublicKeys lists every verification key, sorted by key id.
+func (k *KeyRing) PublicKeys() []PublicKey {
+	keys := make([]PublicKey, 0, len(k.publicKeys))
+	for kid, pub := range k.publicKeys {
+		keys = append(keys, PublicKey{
+			KeyID:     kid,
+			Algorithm: Algorithm,
+			PublicKey: base64.StdEncoding.EncodeToString(pub),
+			Active:    kid == k.activeID,
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].KeyID < keys[j].KeyID })
+	return keys
+}
+
+// Canonicalize serializes a decoded JSON document with object keys sorted,
+// no insignificant whitespace and no HTML escaping. For documents with ASCII
+// keys this matches RFC 8785 (JCS), so clients can use an off-the-shelf JCS
+// implementation to rebuild the signed bytes.
+func Canonicalize(doc interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return unescapeLineSeparators(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+// unescapeLineSeparators undoes the \u2028 and \u2029 escapes encoding/json
+// always writes; JCS leaves those characters as they are.
+func unescapeLineSeparators(data []byte) []byte {
+	if !bytes.Contains(data, []byte(`\u202`)) {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' || i+1 >= len(data) {
+			out = append(out, data[i])
+			continue
+		}
+		if i+5 < len(data) && data[i+1] == 'u' && string(data[i+2:i+5]) == "202" && (data[i+5] == '8' || data[i+5] == '9') {
+			if data[i+5] == '8' {
+				out = append(out, "\u2028"...)
+			} else {
+				out = append(out, "\u2029"...)
+			}
+			i += 5
+			continue
+		}
+		// Keep any other escape whole so an escaped backslash is not
+		// mistaken for the start of the next one.
+		out = append(out, data[i], data[i+1])
+		i++
+	}
+	return out
+}
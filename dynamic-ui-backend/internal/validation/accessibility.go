@@ -0,0 +1,421 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WCAG 2.1 AA contrast minimums and the Material minimum touch target.
+const (
+	minTextContrast      = 4.5
+	minLargeTextContrast = 3.0
+	minGraphicContrast   = 3.0
+	minTapTarget         = 48.0
+
+	defaultFontSize = 14.0
+)
+
+// Contrast checks text and icon colors against the background they are drawn
+// on: the widget's own decoration, the nearest enclosing widget with a
+// background color or gradient, or the screen background. Colors with alpha
+// are blended onto what is below them, and gradients are checked at every
+// stop. Widgets with no known background are skipped, as are unresolved
+// template placeholders such as "{{accent_color}}" on either side.
+type Contrast struct{}
+
+func (Contrast) Name() string { return "contrast" }
+
+func (r Contrast) Check(doc map[string]interface{}) []Issue {
+	issues := make([]Issue, 0)
+
+	check := func(path, what string, fg []rgba, layers [][]rgba, min float64) {
+		bg := flatten(layers)
+		if len(fg) == 0 || len(bg) == 0 || containsUnresolved(fg) {
+			return
+		}
+		worst, worstBG := math.MaxFloat64, rgba{}
+		for _, f := range fg {
+			for _, b := range bg {
+				if ratio := contrastRatio(f.over(b), b); ratio < worst {
+					worst, worstBG = ratio, b
+				}
+			}
+		}
+		if worst < min {
+			issues = append(issues, Issue{Path: path, Rule: r.Name(), Severity: SeverityWarning,
+				Message: fmt.Sprintf("%s contrast %.2f:1 against %s is below %.1f:1", what, worst, worstBG.hex(), min)})
+		}
+	}
+
+	screen := layerColors(doc)
+
+	if appBar, ok := doc["app_bar"].(map[string]interface{}); ok {
+		check("app_bar.text_color", "title", colors(appBar["text_color"]), [][]rgba{layerColors(appBar)}, minTextContrast)
+	}
+
+	Walk(doc, func(path string, node map[string]interface{}, ancestors []map[string]interface{}) {
+		if path == "app_bar" {
+			return
+		}
+		layers := append([][]rgba{layerColors(node)}, backgroundLayers(ancestors, screen)...)
+
+		switch str(node, "type") {
+		case "text":
+			style, _ := node["style"].(map[string]interface{})
+			if style == nil {
+				return
+			}
+			fg := colors(style["color"])
+			if gradient, ok := style["gradient"].(map[string]interface{}); ok {
+				fg = colors(gradient["colors"])
+			}
+			check(path+".style", "text", fg, layers, textContrastMinimum(style))
+		case "button":
+			check(path+".text_color", "button text", colors(node["text_color"]), layers, textContrastMinimum(node))
+		case "text_field":
+			check(path+".text_color", "input text", colors(node["text_color"]), layers, minTextContrast)
+			check(path+".hint_color", "hint text", colors(node["hint_color"]), layers, minTextContrast)
+		case "icon_widget":
+			check(path+".color", "icon", colors(node["color"]), layers, minGraphicContrast)
+		case "feature_item":
+			check(path+".icon_color", "icon", colors(node["icon_color"]), layers, minGraphicContrast)
+		case "rating_bar":
+			check(path+".active_color", "active rating", colors(node["active_color"]), layers, minGraphicContrast)
+		}
+	})
+
+	return issues
+}
+
+// textContrastMinimum applies the relaxed large-text minimum to text of at
+// least 24px, or 18.66px when bold.
+func textContrastMinimum(style map[string]interface{}) float64 {
+	size, ok := number(style["font_size"])
+	if !ok {
+		size = defaultFontSize
+	}
+	if size >= 24 || (size >= 18.66 && isBold(str(style, "font_weight"))) {
+		return minLargeTextContrast
+	}
+	return minTextContrast
+}
+
+func isBold(weight string) bool {
+	switch weight {
+	case "bold", "semi_bold", "semibold", "extra_bold", "black":
+		return true
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(weight, "w"))
+	return err == nil && n >= 600
+}
+
+// SemanticLabels checks that interactive widgets expose something a screen
+// reader can announce: visible text, a hint, or a "semantic_label".
+type SemanticLabels struct{}
+
+func (SemanticLabels) Name() string { return "semantic_labels" }
+
+// selfLabelledWidgets render their own accessible label in the app.
+var selfLabelledWidgets = toSet("load_more_button", "search_header", "search_category_carousel",
+	"category_grid", "brands_carousel", "ads_cell", "advised_goods_grid", "platform_goods_masonry")
+
+func (r SemanticLabels) Check(doc map[string]interface{}) []Issue {
+	issues := make([]Issue, 0)
+
+	missing := func(path, what string) {
+		issues = append(issues, Issue{Path: path, Rule: r.Name(), Severity: SeverityWarning,
+			Message: fmt.Sprintf("%s has no text or semantic_label for screen readers", what)})
+	}
+
+	Walk(doc, func(path string, node map[string]interface{}, _ []map[string]interface{}) {
+		typ := str(node, "type")
+		if selfLabelledWidgets[typ] || str(node, "semantic_label") != "" {
+			return
+		}
+
+		switch typ {
+		case "button":
+			if str(node, "text") == "" {
+				missing(path, "icon-only button")
+			}
+		case "draggable_ai_fab":
+			missing(path, "floating action button")
+		case "rating_bar":
+			missing(path, "rating bar")
+		case "text_field":
+			if str(node, "hint") == "" {
+				missing(path, "text field")
+			}
+		case "survey_group":
+			if options, ok := node["options"].([]interface{}); ok {
+				for i, o := range options {
+					if opt, ok := o.(map[string]interface{}); ok && str(opt, "text") == "" && str(opt, "semantic_label") == "" {
+						missing(fmt.Sprintf("%s.options[%d]", path, i), "survey option")
+					}
+				}
+			}
+		default:
+			if _, interactive := node["action"]; interactive && !hasVisibleText(node) {
+				missing(path, typ+" with an action")
+			}
+		}
+	})
+
+	return issues
+}
+
+func hasVisibleText(node map[string]interface{}) bool {
+	for _, key := range []string{"text", "title", "content", "label"} {
+		if str(node, key) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// TapTargets checks that interactive widgets are at least 48x48 px.
+type TapTargets struct{}
+
+func (TapTargets) Name() string { return "tap_targets" }
+
+func (r TapTargets) Check(doc map[string]interface{}) []Issue {
+	issues := make([]Issue, 0)
+
+	small := func(path, what string, size float64) {
+		issues = append(issues, Issue{Path: path, Rule: r.Name(), Severity: SeverityWarning,
+			Message: fmt.Sprintf("%s tap target is %gpx, below %gpx", what, size, minTapTarget)})
+	}
+
+	Walk(doc, func(path string, node map[string]interface{}, _ []map[string]interface{}) {
+		switch str(node, "type") {
+		case "rating_bar":
+			if size, ok := number(node["icon_size"]); ok && size < minTapTarget {
+				small(path+".icon_size", "rating star", size)
+			}
+			return
+		case "button":
+		default:
+			if _, interactive := node["action"]; !interactive {
+				return
+			}
+		}
+
+		padding := verticalInsets(node["padding"])
+		if height, ok := number(node["height"]); ok && height < minTapTarget {
+			small(path+".height", str(node, "type"), height)
+		} else if size, ok := number(node["size"]); ok && size+padding < minTapTarget {
+			small(path+".size", str(node, "type"), size+padding)
+		}
+		if width, ok := number(node["width"]); ok && width < minTapTarget {
+			small(path+".width", str(node, "type"), width)
+		}
+	})
+
+	return issues
+}
+
+// verticalInsets returns top+bottom of a padding in number or object form.
+func verticalInsets(v interface{}) float64 {
+	if n, ok := number(v); ok {
+		return 2 * n
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	var top, bottom float64
+	if all, ok := number(m["all"]); ok {
+		top, bottom = all, all
+	}
+	if vv, ok := number(m["vertical"]); ok {
+		top, bottom = vv, vv
+	}
+	if x, ok := number(m["top"]); ok {
+		top = x
+	}
+	if x, ok := number(m["bottom"]); ok {
+		bottom = x
+	}
+	return top + bottom
+}
+
+func number(v interface{}) (float64, bool) {
+	n, ok := v.(float64)
+	return n, ok
+}
+
+// rgba is a color with channels in [0, 1].
+type rgba struct {
+	r, g, b, a float64
+}
+
+var white = rgba{1, 1, 1, 1}
+
+// parseColor reads #RGB, #RRGGBB and #RRGGBBAA colors.
+func parseColor(s string) (rgba, bool) {
+	if !strings.HasPrefix(s, "#") {
+		return rgba{}, false
+	}
+	hex := s[1:]
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "FF"
+	}
+	if len(hex) != 8 {
+		return rgba{}, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return rgba{}, false
+	}
+	return rgba{
+		r: float64(v>>24&0xFF) / 255,
+		g: float64(v>>16&0xFF) / 255,
+		b: float64(v>>8&0xFF) / 255,
+		a: float64(v&0xFF) / 255,
+	}, true
+}
+
+// unresolved stands for a template placeholder color, which is only known
+// once the template is instantiated.
+var unresolved = rgba{a: -1}
+
+// colors parses a color or a list of gradient stops, skipping invalid ones.
+// Placeholders are returned as unresolved.
+func colors(v interface{}) []rgba {
+	var values []interface{}
+	switch t := v.(type) {
+	case string:
+		values = []interface{}{t}
+	case []interface{}:
+		values = t
+	}
+	out := make([]rgba, 0, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			if strings.Contains(s, "{{") {
+				out = append(out, unresolved)
+			} else if c, ok := parseColor(s); ok {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// layerColors returns the background a widget paints: its decoration's
+// gradient or color, falling back to its own background properties.
+func layerColors(node map[string]interface{}) []rgba {
+	for _, m := range []interface{}{node["decoration"], node} {
+		props, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if gradient, ok := props["background_gradient"].(map[string]interface{}); ok {
+			if c := colors(gradient["colors"]); len(c) > 0 {
+				return c
+			}
+		}
+		if c := colors(props["background_color"]); len(c) > 0 {
+			return c
+		}
+	}
+	return nil
+}
+
+// backgroundLayers lists the backgrounds under a widget, innermost first.
+func backgroundLayers(ancestors []map[string]interface{}, screen []rgba) [][]rgba {
+	layers := make([][]rgba, 0, len(ancestors)+1)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		layers = append(layers, layerColors(ancestors[i]))
+	}
+	return append(layers, screen)
+}
+
+func containsUnresolved(cs []rgba) bool {
+	for _, c := range cs {
+		if c == unresolved {
+			return true
+		}
+	}
+	return false
+}
+
+// flatten resolves stacked layers (innermost first) into the opaque colors
+// that can appear directly behind a widget. Translucent colors are blended
+// onto every color below them, or onto white at the bottom of the stack.
+// It returns nil when no layer has a color or the visible one is unresolved.
+func flatten(layers [][]rgba) []rgba {
+	for i, layer := range layers {
+		if len(layer) == 0 {
+			continue
+		}
+		if containsUnresolved(layer) {
+			return nil
+		}
+		below := flatten(layers[i+1:])
+		if len(below) == 0 {
+			if hasColor(layers[i+1:]) {
+				return nil
+			}
+			below = []rgba{white}
+		}
+		out := make([]rgba, 0, len(layer))
+		for _, c := range layer {
+			if c.a >= 1 {
+				out = append(out, c)
+				continue
+			}
+			for _, b := range below {
+				out = append(out, c.over(b))
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func hasColor(layers [][]rgba) bool {
+	for _, layer := range layers {
+		if len(layer) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// over alpha-composites c onto an opaque background.
+func (c rgba) over(bg rgba) rgba {
+	return rgba{
+		r: c.r*c.a + bg.r*(1-c.a),
+		g: c.g*c.a + bg.g*(1-c.a),
+		b: c.b*c.a + bg.b*(1-c.a),
+		a: 1,
+	}
+}
+
+func (c rgba) luminance() float64 {
+	channel := func(v float64) float64 {
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(c.r) + 0.7152*channel(c.g) + 0.0722*channel(c.b)
+}
+
+func (c rgba) hex() string {
+	return fmt.Sprintf("#%02X%02X%02X", int(math.Round(c.r*255)), int(math.Round(c.g*255)), int(math.Round(c.b*255)))
+}
+
+func contrastRatio(a, b rgba) float64 {
+	la, lb := a.luminance(), b.luminance()
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
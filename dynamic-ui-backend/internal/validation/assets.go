@@ -0,0 +1,48 @@
+package validation
+
+import "fmt"
+
+// AssetReferences checks that every Lottie "asset" resolves to a registered
+// animation and every "icon" to a registered SVG icon or a known Material
+// icon name.
+type AssetReferences struct {
+	Lotties map[string]bool
+	Icons   map[string]bool
+}
+
+func (AssetReferences) Name() string { return "asset_references" }
+
+func (r AssetReferences) Check(doc map[string]interface{}) []Issue {
+	issues := make([]Issue, 0)
+
+	checkIcon := func(path, name string) {
+		if name == "" || r.Icons[name] || MaterialIcons[name] {
+			return
+		}
+		issues = append(issues, Issue{Path: path, Rule: r.Name(), Severity: SeverityError,
+			Message: fmt.Sprintf("icon '%s' is neither a registered icon nor a known Material icon", name)})
+	}
+
+	Walk(doc, func(path string, node map[string]interface{}, _ []map[string]interface{}) {
+		if str(node, "type") == "lottie" {
+			asset := str(node, "asset")
+			if asset == "" {
+				issues = append(issues, Issue{Path: path, Rule: r.Name(), Severity: SeverityError, Message: "lottie widget has no asset"})
+			} else if !r.Lotties[asset] {
+				issues = append(issues, Issue{Path: path, Rule: r.Name(), Severity: SeverityError,
+					Message: fmt.Sprintf("lottie asset '%s' is not registered", asset)})
+			}
+		}
+
+		checkIcon(path+".icon", str(node, "icon"))
+		if options, ok := node["options"].([]interface{}); ok {
+			for i, o := range options {
+				if opt, ok := o.(map[string]interface{}); ok {
+					checkIcon(fmt.Sprintf("%s.options[%d].icon", path, i), str(opt, "icon"))
+				}
+			}
+		}
+	})
+
+	return issues
+}
@@ -0,0 +1,35 @@
+package validation
+
+// MaterialIcons lists the Material icon names the app bundles. Icons outside
+// this list must be uploaded to the asset registry as SVG.
+var MaterialIcons = toSet(
+	"account_balance_wallet", "account_circle", "add", "add_circle", "add_shopping_cart",
+	"arrow_back", "arrow_downward", "arrow_forward", "arrow_upward", "attach_money",
+	"auto_awesome", "badge", "bolt", "bookmark", "bookmark_border", "business", "business_center",
+	"calendar_today", "call", "camera_alt", "campaign", "cancel", "card_giftcard", "celebration",
+	"chat", "chat_bubble", "check", "check_circle", "chevron_left", "chevron_right", "close",
+	"credit_card", "delete", "delivery_dining", "discount", "done", "done_all", "edit", "email",
+	"error", "error_outline", "explore", "favorite", "favorite_border", "feedback", "filter_list",
+	"flash_on", "gift", "grade", "handshake", "headset_mic", "help", "help_outline", "history",
+	"home", "image", "info", "info_outline", "inventory", "inventory_2", "language", "lightbulb",
+	"local_activity", "local_fire_department", "local_grocery_store", "local_mall", "local_offer",
+	"local_shipping", "location_on", "lock", "login", "logout", "loyalty", "mail", "map", "menu",
+	"mic", "more_horiz", "more_vert", "new_releases", "notifications", "payment", "payments",
+	"people", "percent", "person", "phone", "photo_camera", "price_check", "psychology",
+	"qr_code", "qr_code_scanner", "receipt", "receipt_long", "redeem", "refresh", "remove",
+	"rocket_launch", "savings", "schedule", "search", "search_off", "security", "sell", "send",
+	"sentiment_dissatisfied", "sentiment_neutral", "sentiment_satisfied",
+	"sentiment_very_dissatisfied", "sentiment_very_satisfied", "settings", "share",
+	"shopping_bag", "shopping_basket", "shopping_cart", "smart_toy", "speed", "star",
+	"star_border", "star_half", "storefront", "support_agent", "thumb_down", "thumb_up", "timer",
+	"touch_app", "trending_down", "trending_up", "tune", "verified", "verified_user", "visibility",
+	"visibility_off", "wallet", "warning", "whatshot", "workspace_premium",
+)
+
+func toSet(names ...string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
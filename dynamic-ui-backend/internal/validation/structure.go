@@ -0,0 +1,64 @@
+package validation
+
+import "fmt"
+
+// Structure checks the basic shape every screen must have: a widgets list,
+// a type on every widget and unique widget ids.
+type Structure struct{}
+
+func (Structure) Name() string { return "structure" }
+
+func (r Structure) Check(doc map[string]interface{}) []Issue {
+	issues := make([]Issue, 0)
+
+	if _, ok := doc["widgets"].([]interface{}); !ok {
+		issues = append(issues, Issue{Path: "widgets", Rule: r.Name(), Severity: SeverityError, Message: "screen must have a widgets array"})
+	}
+
+	if access, ok := doc["access"]; ok {
+		if msg := checkAccess(access); msg != "" {
+			issues = append(issues, Issue{Path: "access", Rule: r.Name(), Severity: SeverityError, Message: msg})
+		}
+	}
+
+	seen := make(map[string]string)
+	Walk(doc, func(path string, node map[string]interface{}, _ []map[string]interface{}) {
+		if path != "app_bar" && str(node, "type") == "" {
+			issues = append(issues, Issue{Path: path, Rule: r.Name(), Severity: SeverityError, Message: "widget has no type"})
+		}
+		if id := str(node, "id"); id != "" {
+			if first, dup := seen[id]; dup {
+				issues = append(issues, Issue{Path: path, Rule: r.Name(), Severity: SeverityWarning,
+					Message: fmt.Sprintf("duplicate widget id '%s' (first used at %s)", id, first)})
+			} else {
+				seen[id] = path
+			}
+		}
+	})
+
+	return issues
+}
+
+// checkAccess validates the screen's access block; see services.ScreenAccess.
+func checkAccess(v interface{}) string {
+	block, ok := v.(map[string]interface{})
+	if !ok {
+		return "access must be an object"
+	}
+	roles, _ := block["roles"].([]interface{})
+	switch str(block, "policy") {
+	case "public", "authenticated":
+		return ""
+	case "roles":
+		if len(roles) == 0 {
+			return "access policy 'roles' needs at least one role"
+		}
+		for _, role := range roles {
+			if s, ok := role.(string); !ok || s == "" {
+				return "access.roles must be a list of role names"
+			}
+		}
+		return ""
+	}
+	return fmt.Sprintf("unknown access policy '%s'", str(block, "policy"))
+}
@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is a single finding about a schema document. Path is a JSONPath-like
+// location such as "widgets[2].children[0]".
+type Issue struct {
+	Path     string `json:"path"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// Rule inspects a whole screen document and reports issues.
+type Rule interface {
+	Name() string
+	Check(doc map[string]interface{}) []Issue
+}
+
+type Validator struct {
+	rules []Rule
+}
+
+func New(rules ...Rule) *Validator {
+	return &Validator{rules: rules}
+}
+
+// Validate runs every rule and returns the issues ordered by path.
+func (v *Validator) Validate(doc map[string]interface{}) []Issue {
+	issues := make([]Issue, 0)
+	for _, rule := range v.rules {
+		issues = append(issues, rule.Check(doc)...)
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Path < issues[j].Path })
+	return issues
+}
+
+// HasErrors reports whether any issue has error severity.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Walk visits every widget node of a screen (app bar, widgets, floating
+// action button and their descendants) in document order. The ancestors
+// slice holds the enclosing widgets, outermost first.
+func Walk(doc map[string]interface{}, fn func(path string, node map[string]interface{}, ancestors []map[string]interface{})) {
+	if appBar, ok := doc["app_bar"].(map[string]interface{}); ok {
+		walkNode("app_bar", appBar, nil, fn)
+	}
+	if widgets, ok := doc["widgets"].([]interface{}); ok {
+		for i, w := range widgets {
+			if node, ok := w.(map[string]interface{}); ok {
+				walkNode(fmt.Sprintf("widgets[%d]", i), node, nil, fn)
+			}
+		}
+	}
+	if fab, ok := doc["floating_action_button"].(map[string]interface{}); ok {
+		walkNode("floating_action_button", fab, nil, fn)
+	}
+}
+
+func walkNode(path string, node map[string]interface{}, ancestors []map[string]interface{}, fn func(string, map[string]interface{}, []map[string]interface{})) {
+	fn(path, node, ancestors)
+
+	inner := append(append([]map[string]interface{}{}, ancestors...), node)
+	if children, ok := node["children"].([]interface{}); ok {
+		for i, c := range children {
+			if child, ok := c.(map[string]interface{}); ok {
+				walkNode(fmt.Sprintf("%s.children[%d]", path, i), child, inner, fn)
+			}
+		}
+	}
+	if child, ok := node["child"].(map[string]interface{}); ok {
+		walkNode(path+".child", child, inner, fn)
+	}
+}
+
+func str(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
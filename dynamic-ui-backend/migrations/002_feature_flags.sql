@@ -0,0 +1,16 @@
+-- Feature flags / remote configuration
+CREATE TABLE feature_flags (
+    id SERIAL PRIMARY KEY,
+    key VARCHAR(100) UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    value_type VARCHAR(20) NOT NULL,
+    default_value JSONB NOT NULL,
+    rules JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    created_by INT REFERENCES users(id),
+    updated_by INT REFERENCES users(id)
+);
+
+CREATE INDEX idx_feature_flags_active ON feature_flags(is_active);
@@ -0,0 +1,16 @@
+-- Asset registry (Lottie animations and SVG icons referenced by schemas)
+CREATE TABLE assets (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    version INT NOT NULL,
+    url TEXT NOT NULL,
+    content_hash VARCHAR(64) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT NOW(),
+    created_by INT REFERENCES users(id),
+    UNIQUE (kind, name, version)
+);
+
+CREATE INDEX idx_assets_lookup ON assets(kind, name, is_active);
@@ -0,0 +1,31 @@
+-- Canary rollouts: a candidate screen document served to a share of devices
+-- before it replaces the published one
+CREATE TABLE schema_canaries (
+    id SERIAL PRIMARY KEY,
+    version VARCHAR(50) NOT NULL,
+    screen VARCHAR(100) NOT NULL,
+    revision VARCHAR(64) NOT NULL,
+    document TEXT NOT NULL,
+    percentage INT NOT NULL CHECK (percentage BETWEEN 0 AND 100),
+    error_threshold INT NOT NULL CHECK (error_threshold > 0),
+    error_count INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    halt_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    created_by INT REFERENCES users(id),
+    updated_by INT REFERENCES users(id)
+);
+
+-- At most one running canary per screen
+CREATE UNIQUE INDEX idx_schema_canaries_active ON schema_canaries(version, screen) WHERE status = 'active';
+
+-- Render errors reported by clients, one per device so a single install
+-- cannot halt a rollout on its own
+CREATE TABLE schema_canary_errors (
+    canary_id INT NOT NULL REFERENCES schema_canaries(id) ON DELETE CASCADE,
+    device_id VARCHAR(100) NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (canary_id, device_id)
+);
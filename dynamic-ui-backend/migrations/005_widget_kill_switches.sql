@@ -0,0 +1,19 @@
+-- Widget kill switches: suppress a widget type or a single widget id in every
+-- schema response, optionally only for some platforms / app versions
+CREATE TABLE widget_kill_switches (
+    id SERIAL PRIMARY KEY,
+    widget_type VARCHAR(100) NOT NULL DEFAULT '',
+    widget_id VARCHAR(100) NOT NULL DEFAULT '',
+    platform VARCHAR(20) NOT NULL DEFAULT '',
+    min_app_version VARCHAR(20) NOT NULL DEFAULT '',
+    max_app_version VARCHAR(20) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    created_by INT REFERENCES users(id),
+    updated_by INT REFERENCES users(id),
+    CHECK (widget_type <> '' OR widget_id <> '')
+);
+
+CREATE INDEX idx_widget_kill_switches_active ON widget_kill_switches(is_active);
@@ -0,0 +1,18 @@
+-- Feedback responses: the survey state a client submitted from a screen,
+-- validated against the inputs that screen declared when it was submitted
+CREATE TABLE feedback_responses (
+    id SERIAL PRIMARY KEY,
+    screen VARCHAR(100) NOT NULL,
+    screen_id VARCHAR(100) NOT NULL DEFAULT '',
+    version VARCHAR(20) NOT NULL,
+    schema_version VARCHAR(20) NOT NULL DEFAULT '',
+    revision VARCHAR(64) NOT NULL DEFAULT '',
+    answers JSONB NOT NULL,
+    device_id VARCHAR(100) NOT NULL,
+    platform VARCHAR(20) NOT NULL DEFAULT '',
+    app_version VARCHAR(20) NOT NULL DEFAULT '',
+    user_id INT REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_feedback_responses_screen ON feedback_responses(screen, created_at);
@@ -0,0 +1,19 @@
+-- Form submissions: values posted by a screen's submit_form action, validated
+-- against the form the published screen declared
+CREATE TABLE form_submissions (
+    id SERIAL PRIMARY KEY,
+    form_id VARCHAR(100) NOT NULL,
+    screen VARCHAR(100) NOT NULL,
+    screen_id VARCHAR(100) NOT NULL DEFAULT '',
+    version VARCHAR(20) NOT NULL,
+    schema_version VARCHAR(20) NOT NULL DEFAULT '',
+    revision VARCHAR(64) NOT NULL DEFAULT '',
+    data JSONB NOT NULL,
+    device_id VARCHAR(100) NOT NULL,
+    platform VARCHAR(20) NOT NULL DEFAULT '',
+    app_version VARCHAR(20) NOT NULL DEFAULT '',
+    user_id INT REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_form_submissions_form ON form_submissions(form_id, created_at);
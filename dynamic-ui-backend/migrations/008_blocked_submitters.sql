@@ -0,0 +1,13 @@
+-- Blocked submitters: devices and IPs refused by the public submission
+-- endpoints, either blocked by an admin or automatically for flooding
+CREATE TABLE blocked_submitters (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('device', 'ip')),
+    value VARCHAR(100) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    automatic BOOLEAN NOT NULL DEFAULT false,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    created_by INT REFERENCES users(id),
+    UNIQUE (kind, value)
+);
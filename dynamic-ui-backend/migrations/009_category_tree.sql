@@ -0,0 +1,6 @@
+-- Category tree: departments -> categories -> subcategories. Siblings are
+-- ordered by display_order; a category cannot be its own ancestor
+ALTER TABLE categories ADD COLUMN parent_id INT REFERENCES categories(id);
+ALTER TABLE categories ADD CONSTRAINT categories_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX idx_categories_parent ON categories(parent_id, display_order);
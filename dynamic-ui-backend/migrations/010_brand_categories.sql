@@ -0,0 +1,15 @@
+-- Brands listed under categories. brand_order places a brand among the
+-- brands of a category, category_order places a category among the
+-- categories of a brand.
+CREATE TABLE brand_categories (
+    brand_id INT NOT NULL REFERENCES brands(id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    brand_order INT NOT NULL DEFAULT 0,
+    category_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    created_by INT REFERENCES users(id),
+    PRIMARY KEY (brand_id, category_id)
+);
+
+CREATE INDEX idx_brand_categories_category ON brand_categories(category_id, brand_order);
+CREATE INDEX idx_brand_categories_brand ON brand_categories(brand_id, category_order);
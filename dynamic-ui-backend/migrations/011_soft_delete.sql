@@ -0,0 +1,11 @@
+-- Deleted categories and brands stay in the trash until restored or purged.
+ALTER TABLE categories
+    ADD COLUMN deleted_at TIMESTAMP,
+    ADD COLUMN deleted_by INT REFERENCES users(id);
+
+ALTER TABLE brands
+    ADD COLUMN deleted_at TIMESTAMP,
+    ADD COLUMN deleted_by INT REFERENCES users(id);
+
+CREATE INDEX idx_categories_deleted ON categories(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_brands_deleted ON brands(deleted_at) WHERE deleted_at IS NOT NULL;
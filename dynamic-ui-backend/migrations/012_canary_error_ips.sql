@@ -0,0 +1,4 @@
+-- Render error reports also record the client IP, so one network cannot
+-- halt a canary by reporting under many device IDs.
+ALTER TABLE schema_canary_errors
+    ADD COLUMN ip VARCHAR(45) NOT NULL DEFAULT '';
@@ -0,0 +1,40 @@
+package uischema
+
+// Action is what a button or FAB does when tapped.
+type Action interface {
+	ActionType() string
+}
+
+type Navigate struct {
+	Route string `json:"route"`
+}
+
+type SubmitFeedback struct {
+	Route          string   `json:"route"`
+	IncludeState   []string `json:"include_state"`
+	SuccessMessage string   `json:"success_message,omitempty"`
+	ErrorMessage   string   `json:"error_message,omitempty"`
+}
+
+// SubmitForm posts the included state to the generic form endpoint, which
+// validates it against the form with the same FormID.
+type SubmitForm struct {
+	FormID         string   `json:"form_id"`
+	IncludeState   []string `json:"include_state"`
+	SuccessMessage string   `json:"success_message,omitempty"`
+	ErrorMessage   string   `json:"error_message,omitempty"`
+}
+
+type LoadMore struct {
+	Method string `json:"method"`
+}
+
+func (Navigate) ActionType() string       { return "navigate" }
+func (SubmitFeedback) ActionType() string { return "submit_feedback" }
+func (SubmitForm) ActionType() string     { return "submit_form" }
+func (LoadMore) ActionType() string       { return "load_more" }
+
+func (a Navigate) MarshalJSON() ([]byte, error)       { return withType(a) }
+func (a SubmitFeedback) MarshalJSON() ([]byte, error) { return withType(a) }
+func (a SubmitForm) MarshalJSON() ([]byte, error)     { return withType(a) }
+func (a LoadMore) MarshalJSON() ([]byte, error)       { return withType(a) }
@@ -0,0 +1,17 @@
+// Package uischema is a typed builder for the screen schema JSON the mobile
+// app renders. Every widget and action is a Go struct that serializes to the
+// exact shape used in schemas/, so screens can be generated, diffed and
+// unit-tested in Go instead of being written by hand:
+//
+//	screen := uischema.Screen{
+//		ScreenID: "promo_v1",
+//		Version:  "1.0.0",
+//		Title:    "Promo",
+//		Widgets: []uischema.Widget{
+//			uischema.Text{Content: "Hello", Style: &uischema.TextStyle{FontSize: 18, FontWeight: uischema.FontWeightBold}},
+//			uischema.SizedBox{Height: 12},
+//			uischema.Button{Text: "Open AI", Action: uischema.Navigate{Route: "/aiPage"}},
+//		},
+//	}
+//	data, err := screen.JSON()
+package uischema
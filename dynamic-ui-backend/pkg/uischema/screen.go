@@ -0,0 +1,79 @@
+package uischema
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+type AppBar struct {
+	Type            string    `json:"type,omitempty"`
+	Title           string    `json:"title"`
+	Pinned          bool      `json:"pinned,omitempty"`
+	IsCollapsible   bool      `json:"is_collapsible,omitempty"`
+	BackgroundColor string    `json:"background_color,omitempty"`
+	TextColor       string    `json:"text_color,omitempty"`
+	Elevation       *float64  `json:"elevation,omitempty"`
+	TitleGradient   *Gradient `json:"title_gradient,omitempty"`
+}
+
+// Access restricts who may fetch a screen. Policy is "public",
+// "authenticated" or "roles"; Roles lists the allowed roles for the latter.
+type Access struct {
+	Policy string   `json:"policy"`
+	Roles  []string `json:"roles,omitempty"`
+}
+
+// BackgroundAnimation is drawn behind the whole screen. ParticleSize and
+// Speed are [min, max] ranges.
+type BackgroundAnimation struct {
+	Type          string    `json:"type"`
+	ParticleColor string    `json:"particle_color,omitempty"`
+	ParticleCount int       `json:"particle_count,omitempty"`
+	ParticleSize  []float64 `json:"particle_size,omitempty"`
+	Speed         []float64 `json:"speed,omitempty"`
+	GlowEffect    bool      `json:"glow_effect,omitempty"`
+	GlowColor     string    `json:"glow_color,omitempty"`
+}
+
+// Screen is a complete screen document as served by GET /api/v1/ui.
+type Screen struct {
+	ScreenID             string               `json:"screen_id"`
+	Version              string               `json:"version"`
+	Title                string               `json:"title,omitempty"`
+	Access               *Access              `json:"access,omitempty"`
+	BackgroundColor      string               `json:"background_color,omitempty"`
+	BackgroundAnimation  *BackgroundAnimation `json:"background_animation,omitempty"`
+	AppBar               *AppBar              `json:"app_bar,omitempty"`
+	Widgets              []Widget             `json:"widgets"`
+	FloatingActionButton Widget               `json:"floating_action_button,omitempty"`
+}
+
+// JSON renders the screen the way files under schemas/ are written: two
+// space indentation and no HTML escaping.
+func (s Screen) JSON() ([]byte, error) {
+	if s.Widgets == nil {
+		s.Widgets = []Widget{}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Document returns the screen as a decoded JSON document, the form the
+// validation rules and schema services operate on.
+func (s Screen) Document() (map[string]interface{}, error) {
+	data, err := s.JSON()
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
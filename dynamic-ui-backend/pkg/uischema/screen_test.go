@@ -0,0 +1,1022 @@
+package uischema_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"dynamic-ui-backend/pkg/uischema"
+)
+
+// The screens under schemas/v1, rebuilt with the builder. They have to
+// serialize to the same JSON as the files.
+func TestScreensMatchSchemaFiles(t *testing.T) {
+	tests := []struct {
+		file   string
+		screen uischema.Screen
+	}{
+		{"home.json", homeScreen()},
+		{"survey.json", surveyScreen()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			want, err := os.ReadFile(filepath.Join("..", "..", "schemas", "v1", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.screen.JSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var wantDoc, gotDoc interface{}
+			if err := json.Unmarshal(want, &wantDoc); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(got, &gotDoc); err != nil {
+				t.Fatal(err)
+			}
+			if diff := firstDiff("$", wantDoc, gotDoc); diff != "" {
+				t.Errorf("built screen differs from %s: %s", tt.file, diff)
+			}
+		})
+	}
+}
+
+func TestWidgetTypeComesFirst(t *testing.T) {
+	data, err := json.Marshal(uischema.Text{Common: uischema.Common{ID: "title"}, Content: "Hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"text","id":"title","content":"Hi"}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+
+	data, err = json.Marshal(uischema.SearchCategoryCarousel{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"search_category_carousel"}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+// firstDiff describes the first difference between two decoded JSON values,
+// or returns "" when they are equal.
+func firstDiff(path string, want, got interface{}) string {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return fmt.Sprintf("%s: want an object, got %v", path, got)
+		}
+		keys := make([]string, 0, len(w)+len(g))
+		for k := range w {
+			keys = append(keys, k)
+		}
+		for k := range g {
+			if _, ok := w[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			wv, wok := w[k]
+			gv, gok := g[k]
+			switch {
+			case !wok:
+				return fmt.Sprintf("%s.%s: unexpected %v", path, k, gv)
+			case !gok:
+				return fmt.Sprintf("%s.%s: missing, want %v", path, k, wv)
+			}
+			if diff := firstDiff(path+"."+k, wv, gv); diff != "" {
+				return diff
+			}
+		}
+		return ""
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return fmt.Sprintf("%s: want %d items, got %v", path, len(w), got)
+		}
+		for i := range w {
+			if diff := firstDiff(fmt.Sprintf("%s[%d]", path, i), w[i], g[i]); diff != "" {
+				return diff
+			}
+		}
+		return ""
+	}
+	if !reflect.DeepEqual(want, got) {
+		return fmt.Sprintf("%s: want %v, got %v", path, want, got)
+	}
+	return ""
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func homeScreen() uischema.Screen {
+	return uischema.Screen{
+		ScreenID:        "home_v1",
+		Version:         "1.0.0",
+		Title:           "SAHIY - Bosh sahifa",
+		BackgroundColor: "#F5F5F5",
+		AppBar: &uischema.AppBar{
+			Type:            "search_header",
+			Pinned:          true,
+			Title:           "productName",
+			BackgroundColor: "#FFFFFF",
+			IsCollapsible:   true,
+		},
+		Widgets: []uischema.Widget{
+			uischema.SearchCategoryCarousel{
+				Common: uischema.Common{ID: "search_categories_section", Margin: uischema.LTRB(14, 6, 14, 0)},
+			},
+			uischema.AdsCell{
+				Common:  uischema.Common{ID: "ads_banner", Padding: uischema.Uniform(14)},
+				AdsType: 5,
+			},
+			uischema.SizedBox{
+				Common: uischema.Common{ID: "sized_box_1"},
+				Height: 12,
+			},
+			uischema.CategoryGrid{
+				Common: uischema.Common{ID: "category_section", Margin: uischema.LTRB(14, 0, 14, 0)},
+				Decoration: &uischema.Decoration{
+					BackgroundColor: "transparent",
+					BorderRadius:    16,
+				},
+			},
+			uischema.SizedBox{
+				Common: uischema.Common{ID: "sized_box_2"},
+				Height: 16,
+			},
+			uischema.BrandsCarousel{
+				Common: uischema.Common{ID: "brands_section", Margin: uischema.LTRB(14, 0, 14, 0)},
+			},
+			uischema.SizedBox{
+				Common: uischema.Common{ID: "sized_box_3"},
+				Height: 16,
+			},
+			uischema.SectionHeader{
+				Common: uischema.Common{ID: "featured_products_header", Margin: uischema.LTRB(14, 8, 14, 8)},
+				Title:  "Top tavsiyalar",
+			},
+			uischema.AdvisedGoodsGrid{
+				Common:          uischema.Common{ID: "featured_products", Margin: uischema.LTRB(14, 0, 14, 16)},
+				StateKey:        "displayGoodsList",
+				LoadingStateKey: "advisedLoading",
+				UseStatic:       true,
+			},
+			uischema.SectionHeader{
+				Common: uischema.Common{ID: "recommended_header", Margin: uischema.LTRB(14, 0, 14, 8)},
+				Title:  "Top tavsiyalar",
+			},
+			uischema.PlatformGoodsMasonry{
+				Common:           uischema.Common{ID: "recommended_products_grid", Margin: uischema.LTRB(14, 0, 14, 12)},
+				StateKey:         "loadingUtil",
+				CrossAxisCount:   2,
+				MainAxisSpacing:  12,
+				CrossAxisSpacing: 12,
+				ShowEmptyState:   true,
+				ShowShimmer:      true,
+			},
+			uischema.LoadMoreButton{
+				Common: uischema.Common{ID: "load_more_footer", Margin: uischema.LTRB(14, 0, 14, 60)},
+				Action: uischema.LoadMore{
+					Method: "loadMoreRecommendedGoods",
+				},
+			},
+		},
+		FloatingActionButton: uischema.DraggableAIFab{
+			Action: uischema.Navigate{
+				Route: "/aiPage",
+			},
+		},
+	}
+}
+
+func surveyScreen() uischema.Screen {
+	return uischema.Screen{
+		ScreenID:        "survey_v1",
+		Version:         "1.0.0",
+		Title:           "SAHIY - Yangiliklar va Imkoniyatlar",
+		BackgroundColor: "#0A2937",
+		BackgroundAnimation: &uischema.BackgroundAnimation{
+			Type:          "floating_particles",
+			ParticleColor: "#F4D58930",
+			ParticleCount: 30,
+			ParticleSize:  []float64{2, 6},
+			Speed:         []float64{1, 3},
+			GlowEffect:    true,
+			GlowColor:     "#FAF1B550",
+		},
+		AppBar: &uischema.AppBar{
+			Title:           "SAHIY",
+			BackgroundColor: "#0A2937",
+			TextColor:       "#FAF1B5",
+			Elevation:       ptr(0),
+			TitleGradient: &uischema.Gradient{
+				Colors: []string{"#FAF1B5", "#F4D589", "#B27533"},
+				Begin:  uischema.TopCenter,
+				End:    uischema.BottomCenter,
+			},
+		},
+		Widgets: []uischema.Widget{
+			uischema.Container{
+				Common: uischema.Common{ID: "announcement_banner", Padding: uischema.All(20), Margin: uischema.LTRB(16, 12, 16, 8), Animation: &uischema.Animation{
+					Type:     "fade_scale",
+					Duration: 800,
+					Delay:    intPtr(0),
+				}},
+				Decoration: &uischema.Decoration{
+					BackgroundGradient: &uischema.Gradient{
+						Colors: []string{"#FFFEF0", "#FFF8D8", "#FFE89F"},
+						Begin:  uischema.TopLeft,
+						End:    uischema.BottomRight,
+					},
+					BorderRadius: 20,
+					Shadow: &uischema.Shadow{
+						Color:      "#F4D58970",
+						BlurRadius: 25,
+						Offset: uischema.Offset{
+							X: 0,
+							Y: 10,
+						},
+					},
+				},
+				Children: []uischema.Widget{
+					uischema.Row{
+						CrossAxisAlignment: uischema.CrossAxisCenter,
+						Children: []uischema.Widget{
+							uischema.Container{
+								Common: uischema.Common{Padding: uischema.All(12)},
+								Decoration: &uischema.Decoration{
+									BackgroundGradient: &uischema.Gradient{
+										Colors: []string{"#0A2937", "#0F3748"},
+										Begin:  uischema.TopLeft,
+										End:    uischema.BottomRight,
+									},
+									BorderRadius: 12,
+									Shadow: &uischema.Shadow{
+										Color:      "#0A293750",
+										BlurRadius: 8,
+										Offset: uischema.Offset{
+											X: 0,
+											Y: 4,
+										},
+									},
+								},
+								Children: []uischema.Widget{
+									uischema.IconWidget{
+										Icon:  "celebration",
+										Size:  32,
+										Color: "#FFE89F",
+									},
+								},
+							},
+							uischema.SizedBox{
+								Width: 16,
+							},
+							uischema.Column{
+								CrossAxisAlignment: uischema.CrossAxisStart,
+								Flex:               1,
+								Children: []uischema.Widget{
+									uischema.Text{
+										Content: "YANGILIKNI ESHITDINGIZMI?",
+										Style: &uischema.TextStyle{
+											FontSize:   18,
+											FontWeight: uischema.FontWeightBold,
+											Color:      "#0A2937",
+										},
+									},
+									uischema.SizedBox{
+										Height: 4,
+									},
+									uischema.Text{
+										Content: "Chakana mijozlar uchun bepul yetkazib berish boshlandi!",
+										Style: &uischema.TextStyle{
+											FontSize: 14,
+											Color:    "#0F3748",
+											Height:   1.3,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			uischema.Container{
+				Common: uischema.Common{ID: "new_features_section", Padding: uischema.All(20), Margin: &uischema.EdgeInsets{
+					Left:   ptr(16),
+					Right:  ptr(16),
+					Bottom: ptr(12),
+				}, Animation: &uischema.Animation{
+					Type:     "fade_scale",
+					Duration: 800,
+					Delay:    intPtr(100),
+				}},
+				Decoration: &uischema.Decoration{
+					BackgroundGradient: &uischema.Gradient{
+						Colors: []string{"#0F3748", "#132D3C"},
+						Begin:  uischema.TopLeft,
+						End:    uischema.BottomRight,
+					},
+					BorderRadius: 20,
+					Border: &uischema.Border{
+						Color: "#2A5F75",
+						Width: 1.5,
+					},
+					Shadow: &uischema.Shadow{
+						Color:      "#00000050",
+						BlurRadius: 20,
+						Offset: uischema.Offset{
+							X: 0,
+							Y: 8,
+						},
+					},
+				},
+				Children: []uischema.Widget{
+					uischema.Text{
+						Content: "Yangi Imkoniyatlar",
+						Style: &uischema.TextStyle{
+							FontSize:   22,
+							FontWeight: uischema.FontWeightBold,
+							Gradient: &uischema.Gradient{
+								Colors: []string{"#FAF1B5", "#F4D589", "#B27533"},
+							},
+						},
+					},
+					uischema.SizedBox{
+						Height: 16,
+					},
+					uischema.FeatureItem{
+						Icon:        "local_shipping",
+						IconColor:   "#34D399",
+						Title:       "Bepul Yetkazib Berish",
+						Description: "Chakana xaridlar uchun mutlaqo bepul yetkazib berish xizmati",
+						Badge:       "YANGI",
+						BadgeColor:  "#34D399",
+					},
+					uischema.SizedBox{
+						Height: 12,
+					},
+					uischema.FeatureItem{
+						Icon:        "business_center",
+						IconColor:   "#60A5FA",
+						Title:       "Ulgurji mijozlarga chegirmalar",
+						Description: "Bizness egalariga maxsus manfaatli yetkazib berish",
+						Badge:       "BIZNESS",
+						BadgeColor:  "#60A5FA",
+					},
+					uischema.SizedBox{
+						Height: 12,
+					},
+					uischema.FeatureItem{
+						Icon:        "psychology",
+						IconColor:   "#A78BFA",
+						Title:       "SAHIY AI Assistant",
+						Description: "Mahsulotlarni solishtirish, narx tahlili va ovozli/rasmli qidiruv",
+						Badge:       "AI",
+						BadgeColor:  "#A78BFA",
+					},
+					uischema.SizedBox{
+						Height: 12,
+					},
+					uischema.FeatureItem{
+						Icon:        "support_agent",
+						IconColor:   "#F97316",
+						Title:       "24/7 Qo'llab-quvvatlash",
+						Description: "Ilova orqali 24/7 va filiallarda (10:00-19:00) yordam",
+						Badge:       "24/7",
+						BadgeColor:  "#F97316",
+					},
+					uischema.SizedBox{
+						Height: 12,
+					},
+					uischema.FeatureItem{
+						Icon:        "inventory_2",
+						IconColor:   "#FBBF24",
+						Title:       "Barcha turdagi mahsulotlari",
+						Description: "Barcha brendlarning tasdiqlangan va arzon mahsulotlari",
+						Badge:       "TOP",
+						BadgeColor:  "#FBBF24",
+					},
+				},
+			},
+			uischema.Container{
+				Common: uischema.Common{ID: "feedback_section", Padding: uischema.All(24), Margin: &uischema.EdgeInsets{
+					Left:   ptr(16),
+					Right:  ptr(16),
+					Bottom: ptr(12),
+				}, Animation: &uischema.Animation{
+					Type:     "fade_scale",
+					Duration: 800,
+					Delay:    intPtr(300),
+				}},
+				Decoration: &uischema.Decoration{
+					BackgroundGradient: &uischema.Gradient{
+						Colors: []string{"#0F3748", "#132D3C"},
+						Begin:  uischema.TopCenter,
+						End:    uischema.BottomCenter,
+					},
+					BorderRadius: 20,
+					Border: &uischema.Border{
+						Color: "#2A5F75",
+						Width: 1.5,
+					},
+					Shadow: &uischema.Shadow{
+						Color:      "#00000050",
+						BlurRadius: 20,
+						Offset: uischema.Offset{
+							X: 0,
+							Y: 8,
+						},
+					},
+				},
+				Children: []uischema.Widget{
+					uischema.Text{
+						Content: "Fikringiz Muhim!",
+						Style: &uischema.TextStyle{
+							FontSize:   22,
+							FontWeight: uischema.FontWeightBold,
+							Gradient: &uischema.Gradient{
+								Colors: []string{"#FAF1B5", "#F4D589", "#B27533"},
+							},
+							TextAlign: uischema.TextAlignCenter,
+						},
+					},
+					uischema.SizedBox{
+						Height: 12,
+					},
+					uischema.Text{
+						Content: "Sizga yana qanday imkoniyatlar kerak?",
+						Style: &uischema.TextStyle{
+							FontSize:  15,
+							Color:     "#D1E0E8",
+							TextAlign: uischema.TextAlignCenter,
+							Height:    1.4,
+						},
+					},
+					uischema.SizedBox{
+						Height: 20,
+					},
+					uischema.SurveyGroup{
+						StateKey: "survey_selections",
+						Options: []uischema.SurveyOption{
+							uischema.SurveyOption{
+								Icon:  "payments",
+								Text:  "Bo'lib to'lash imkoniyati",
+								Value: "installment",
+							},
+							uischema.SurveyOption{
+								Icon:  "speed",
+								Text:  "Tezroq yetkazib berish",
+								Value: "faster_delivery",
+							},
+							uischema.SurveyOption{
+								Icon:  "local_offer",
+								Text:  "Ko'proq mahsulotlar ko`rsatish",
+								Value: "more_discounts",
+							},
+							uischema.SurveyOption{
+								Icon:  "verified",
+								Text:  "Premium mijoz dasturi",
+								Value: "premium_membership",
+							},
+						},
+					},
+				},
+			},
+			uischema.Container{
+				Common: uischema.Common{ID: "free_delivery_usage", Padding: uischema.All(20), Margin: &uischema.EdgeInsets{
+					Left:   ptr(16),
+					Right:  ptr(16),
+					Bottom: ptr(12),
+				}, Animation: &uischema.Animation{
+					Type:     "fade_scale",
+					Duration: 800,
+					Delay:    intPtr(250),
+				}},
+				Decoration: &uischema.Decoration{
+					BackgroundGradient: &uischema.Gradient{
+						Colors: []string{"#0F3748", "#132D3C"},
+						Begin:  uischema.TopLeft,
+						End:    uischema.BottomRight,
+					},
+					BorderRadius: 20,
+					Border: &uischema.Border{
+						Color: "#2A5F75",
+						Width: 1.5,
+					},
+					Shadow: &uischema.Shadow{
+						Color:      "#00000050",
+						BlurRadius: 20,
+						Offset: uischema.Offset{
+							X: 0,
+							Y: 8,
+						},
+					},
+				},
+				Children: []uischema.Widget{
+					uischema.Text{
+						Content: "🚚 Bepul Yetkazib Berish",
+						Style: &uischema.TextStyle{
+							FontSize:   20,
+							FontWeight: uischema.FontWeightBold,
+							Gradient: &uischema.Gradient{
+								Colors: []string{"#FAF1B5", "#F4D589", "#B27533"},
+							},
+						},
+					},
+					uischema.SizedBox{
+						Height: 16,
+					},
+					uischema.Text{
+						Content: "Bepul yetkazib berish imkoniyatidan foydalandingizmi?",
+						Style: &uischema.TextStyle{
+							FontSize: 15,
+							Color:    "#D1E0E8",
+							Height:   1.4,
+						},
+					},
+					uischema.SizedBox{
+						Height: 12,
+					},
+					uischema.SurveyGroup{
+						StateKey:        "used_free_delivery",
+						SingleSelection: true,
+						Options: []uischema.SurveyOption{
+							uischema.SurveyOption{
+								Icon:  "check_circle",
+								Text:  "Ha",
+								Value: "yes",
+							},
+							uischema.SurveyOption{
+								Icon:  "cancel",
+								Text:  "Yo'q",
+								Value: "no",
+							},
+							uischema.SurveyOption{
+								Icon:  "trending_down",
+								Text:  "Narxlar arzonlashini kutyapman",
+								Value: "waiting_price_drop",
+							},
+						},
+					},
+				},
+			},
+			uischema.Container{
+				Common: uischema.Common{ID: "wholesale_discount_usage", Padding: uischema.All(20), Margin: &uischema.EdgeInsets{
+					Left:   ptr(16),
+					Right:  ptr(16),
+					Bottom: ptr(12),
+				}, Animation: &uischema.Animation{
+					Type:     "fade_scale",
+					Duration: 800,
+					Delay:    intPtr(275),
+				}},
+				Decoration: &uischema.Decoration{
+					BackgroundGradient: &uischema.Gradient{
+						Colors: []string{"#0F3748", "#132D3C"},
+						Begin:  uischema.TopLeft,
+						End:    uischema.BottomRight,
+					},
+					BorderRadius: 20,
+					Border: &uischema.Border{
+						Color: "#2A5F75",
+						Width: 1.5,
+					},
+					Shadow: &uischema.Shadow{
+						Color:      "#00000050",
+						BlurRadius: 20,
+						Offset: uischema.Offset{
+							X: 0,
+							Y: 8,
+						},
+					},
+				},
+				Children: []uischema.Widget{
+					uischema.Text{
+						Content: "💼 Ulgurji Chegirmalar",
+						Style: &uischema.TextStyle{
+							FontSize:   20,
+							FontWeight: uischema.FontWeightBold,
+							Gradient: &uischema.Gradient{
+								Colors: []string{"#FAF1B5", "#F4D589", "#B27533"},
+							},
+						},
+					},
+					uischema.SizedBox{
+						Height: 16,
+					},
+					uischema.Text{
+						Content: "Ulgurji mijozlar uchun yetkazib berishdagi chegirmalardan foydalandingizmi?",
+						Style: &uischema.TextStyle{
+							FontSize: 15,
+							Color:    "#D1E0E8",
+							Height:   1.4,
+						},
+					},
+					uischema.SizedBox{
+						Height: 12,
+					},
+					uischema.SurveyGroup{
+						StateKey:        "used_wholesale_discount",
+						SingleSelection: true,
+						Options: []uischema.SurveyOption{
+							uischema.SurveyOption{
+								Icon:  "check_circle",
+								Text:  "Ha",
+								Value: "yes",
+							},
+							uischema.SurveyOption{
+								Icon:  "cancel",
+								Text:  "Yo'q",
+								Value: "no",
+							},
+							uischema.SurveyOption{
+								Icon:  "search_off",
+								Text:  "Kerakli mahsulotlarni topolmayapman",
+								Value: "cannot_find_products",
+							},
+						},
+					},
+				},
+			},
+			uischema.Container{
+				Common: uischema.Common{ID: "customer_service_rating", Padding: uischema.All(20), Margin: &uischema.EdgeInsets{
+					Left:   ptr(16),
+					Right:  ptr(16),
+					Bottom: ptr(12),
+				}, Animation: &uischema.Animation{
+					Type:     "fade_scale",
+					Duration: 800,
+					Delay:    intPtr(300),
+				}},
+				Decoration: &uischema.Decoration{
+					BackgroundGradient: &uischema.Gradient{
+						Colors: []string{"#0F3748", "#132D3C"},
+						Begin:  uischema.TopLeft,
+						End:    uischema.BottomRight,
+					},
+					BorderRadius: 20,
+					Border: &uischema.Border{
+						Color: "#2A5F75",
+						Width: 1.5,
+					},
+					Shadow: &uischema.Shadow{
+						Color:      "#00000050",
+						BlurRadius: 20,
+						Offset: uischema.Offset{
+							X: 0,
+							Y: 8,
+						},
+					},
+				},
+				Children: []uischema.Widget{
+					uischema.Text{
+						Content: "🎯 Umumiy Baholash",
+						Style: &uischema.TextStyle{
+							FontSize:   20,
+							FontWeight: uischema.FontWeightBold,
+							Gradient: &uischema.Gradient{
+								Colors: []string{"#FAF1B5", "#F4D589", "#B27533"},
+							},
+						},
+					},
+					uischema.SizedBox{
+						Height: 16,
+					},
+					uischema.Text{
+						Content: "Qo'llab-quvvatlash xizmatimiz qanday?",
+						Style: &uischema.TextStyle{
+							FontSize: 15,
+							Color:    "#D1E0E8",
+							Height:   1.4,
+						},
+					},
+					uischema.SizedBox{
+						Height: 12,
+					},
+					uischema.RatingBar{
+						StateKey:      "support_rating",
+						MaxRating:     5,
+						InitialRating: 0,
+						IconSize:      40,
+						ActiveColor:   "#FAF1B5",
+						InactiveColor: "#2A5F75",
+					},
+					uischema.SizedBox{
+						Height: 16,
+					},
+					uischema.Text{
+						Content: "Do'stlaringizga tavsiya qilasizmi?",
+						Style: &uischema.TextStyle{
+							FontSize: 15,
+							Color:    "#D1E0E8",
+							Height:   1.4,
+						},
+					},
+					uischema.SizedBox{
+						Height: 12,
+					},
+					uischema.SurveyGroup{
+						StateKey:        "would_recommend",
+						SingleSelection: true,
+						Options: []uischema.SurveyOption{
+							uischema.SurveyOption{
+								Icon:  "thumb_up",
+								Text:  "Albatta tavsiya qilaman",
+								Value: "definitely",
+							},
+							uischema.SurveyOption{
+								Icon:  "sentiment_satisfied",
+								Text:  "Ehtimol tavsiya qilaman",
+								Value: "probably",
+							},
+							uischema.SurveyOption{
+								Icon:  "sentiment_neutral",
+								Text:  "Bilmayman",
+								Value: "unsure",
+							},
+							uischema.SurveyOption{
+								Icon:  "thumb_down",
+								Text:  "Tavsiya qilmayman",
+								Value: "no",
+							},
+						},
+					},
+				},
+			},
+			uischema.Container{
+				Common: uischema.Common{ID: "feedback_section", Padding: uischema.All(24), Margin: &uischema.EdgeInsets{
+					Left:   ptr(16),
+					Right:  ptr(16),
+					Bottom: ptr(12),
+				}, Animation: &uischema.Animation{
+					Type:     "fade_scale",
+					Duration: 800,
+					Delay:    intPtr(350),
+				}},
+				Decoration: &uischema.Decoration{
+					BackgroundGradient: &uischema.Gradient{
+						Colors: []string{"#0F3748", "#132D3C"},
+						Begin:  uischema.TopCenter,
+						End:    uischema.BottomCenter,
+					},
+					BorderRadius: 20,
+					Border: &uischema.Border{
+						Color: "#2A5F75",
+						Width: 1.5,
+					},
+					Shadow: &uischema.Shadow{
+						Color:      "#00000050",
+						BlurRadius: 20,
+						Offset: uischema.Offset{
+							X: 0,
+							Y: 8,
+						},
+					},
+				},
+				Children: []uischema.Widget{
+					uischema.Text{
+						Content: "💬 Qo'shimcha Fikrlaringiz",
+						Style: &uischema.TextStyle{
+							FontSize:   20,
+							FontWeight: uischema.FontWeightBold,
+							Gradient: &uischema.Gradient{
+								Colors: []string{"#FAF1B5", "#F4D589", "#B27533"},
+							},
+						},
+					},
+					uischema.SizedBox{
+						Height: 12,
+					},
+					uischema.Text{
+						Content: "Bizni yaxshilash uchun takliflaringiz",
+						Style: &uischema.TextStyle{
+							FontSize: 14,
+							Color:    "#8B9DAA",
+							Height:   1.3,
+						},
+					},
+					uischema.SizedBox{
+						Height: 16,
+					},
+					uischema.TextField{
+						ControllerKey:      "feedback_controller",
+						StateKey:           "feedback_text",
+						Hint:               "Masalan: Mobil ilova juda yaxshi, lekin...",
+						MaxLines:           5,
+						BackgroundColor:    "#1A4A5C",
+						TextColor:          "#FFFEF0",
+						HintColor:          "#8B9DAA",
+						BorderColor:        "#2A5F75",
+						FocusedBorderColor: "#FFE89F",
+					},
+					uischema.SizedBox{
+						Height: 20,
+					},
+					uischema.Button{
+						Text:         "Fikrlarni Yuborish",
+						Icon:         "send",
+						Height:       56,
+						BorderRadius: 16,
+						BackgroundGradient: &uischema.Gradient{
+							Colors: []string{"#FFFEF0", "#FFE89F", "#F4D589"},
+						},
+						TextColor:  "#0A2937",
+						FontSize:   16,
+						FontWeight: uischema.FontWeightBold,
+						Shadow: &uischema.Shadow{
+							Color:      "#F4D58960",
+							BlurRadius: 15,
+							Offset: uischema.Offset{
+								X: 0,
+								Y: 6,
+							},
+						},
+						Action: uischema.SubmitFeedback{
+							Route:          "/feedback",
+							IncludeState:   []string{"used_free_delivery", "delivery_speed", "price_comparison", "price_satisfaction_rating", "ai_features_used", "ai_usefulness_rating", "desired_features", "support_rating", "would_recommend", "feedback_text"},
+							SuccessMessage: "Rahmat! Fikringiz bizga juda muhim 💚",
+							ErrorMessage:   "Xatolik yuz berdi. Qayta urinib ko'ring",
+						},
+					},
+				},
+			},
+			uischema.Container{
+				Common: uischema.Common{ID: "ai_showcase", Padding: uischema.All(24), Margin: &uischema.EdgeInsets{
+					Left:   ptr(16),
+					Right:  ptr(16),
+					Bottom: ptr(12),
+				}, Animation: &uischema.Animation{
+					Type:     "fade_scale",
+					Duration: 800,
+					Delay:    intPtr(200),
+				}},
+				Decoration: &uischema.Decoration{
+					BackgroundGradient: &uischema.Gradient{
+						Colors: []string{"#132D3C", "#0F3748"},
+						Begin:  uischema.TopLeft,
+						End:    uischema.BottomRight,
+					},
+					BorderRadius: 20,
+					Border: &uischema.Border{
+						Color: "#2A5F75",
+						Width: 1.5,
+					},
+					Shadow: &uischema.Shadow{
+						Color:      "#00000050",
+						BlurRadius: 20,
+						Offset: uischema.Offset{
+							X: 0,
+							Y: 8,
+						},
+					},
+				},
+				Children: []uischema.Widget{
+					uischema.Row{
+						MainAxisAlignment:  uischema.MainAxisSpaceBetween,
+						CrossAxisAlignment: uischema.CrossAxisCenter,
+						Children: []uischema.Widget{
+							uischema.Column{
+								CrossAxisAlignment: uischema.CrossAxisStart,
+								Flex:               1,
+								Children: []uischema.Widget{
+									uischema.Text{
+										Content: "SAHIY AI",
+										Style: &uischema.TextStyle{
+											FontSize:   24,
+											FontWeight: uischema.FontWeightBold,
+											Gradient: &uischema.Gradient{
+												Colors: []string{"#FAF1B5", "#F4D589", "#B27533"},
+											},
+										},
+									},
+									uischema.SizedBox{
+										Height: 8,
+									},
+									uischema.Text{
+										Content: "• Ovoz bilan qidirish\n• Rasm bilan qidirish\n• Narx tahlili\n• Sifat taqqoslash",
+										Style: &uischema.TextStyle{
+											FontSize: 14,
+											Color:    "#D1E0E8",
+											Height:   1.5,
+										},
+									},
+								},
+							},
+							uischema.Lottie{
+								Asset:  "ai_robot",
+								BoxFit: "contain",
+								Repeat: true,
+							},
+						},
+					},
+					uischema.SizedBox{
+						Height: 16,
+					},
+					uischema.Button{
+						Common: uischema.Common{Animation: &uischema.Animation{
+							Type:     "pulse",
+							Duration: 1500,
+							Repeat:   true,
+						}},
+						Text:         "AI'ni Sinab Ko'ring",
+						Icon:         "touch_app",
+						Height:       52,
+						BorderRadius: 26,
+						BackgroundGradient: &uischema.Gradient{
+							Colors: []string{"#FFFEF0", "#FFE89F", "#F4D589"},
+						},
+						TextColor:  "#0A2937",
+						FontWeight: uischema.FontWeightBold,
+						Action: uischema.Navigate{
+							Route: "/aiPage",
+						},
+					},
+				},
+			},
+			uischema.Container{
+				Common: uischema.Common{ID: "stats_section", Padding: uischema.All(20), Margin: &uischema.EdgeInsets{
+					Left:   ptr(16),
+					Right:  ptr(16),
+					Bottom: ptr(20),
+				}, Animation: &uischema.Animation{
+					Type:     "fade_scale",
+					Duration: 800,
+					Delay:    intPtr(500),
+				}},
+				Decoration: &uischema.Decoration{
+					BackgroundColor: "#132D3C",
+					BorderRadius:    20,
+					Border: &uischema.Border{
+						Color: "#2A5F75",
+						Width: 1.5,
+					},
+					Shadow: &uischema.Shadow{
+						Color:      "#00000050",
+						BlurRadius: 20,
+						Offset: uischema.Offset{
+							X: 0,
+							Y: 8,
+						},
+					},
+				},
+				Children: []uischema.Widget{
+					uischema.Text{
+						Content: "Bizning Natijalar",
+						Style: &uischema.TextStyle{
+							FontSize:   18,
+							FontWeight: uischema.FontWeightBold,
+							Gradient: &uischema.Gradient{
+								Colors: []string{"#FAF1B5", "#F4D589"},
+							},
+						},
+					},
+					uischema.SizedBox{
+						Height: 16,
+					},
+					uischema.Row{
+						MainAxisAlignment: uischema.MainAxisSpaceAround,
+						Children: []uischema.Widget{
+							uischema.StatItem{
+								Value:    "1 milliard+",
+								Label:    "Mahsulotlar",
+								Icon:     "inventory",
+								Gradient: true,
+							},
+							uischema.StatItem{
+								Value:    "250K+",
+								Label:    "Mijozlar",
+								Icon:     "people",
+								Gradient: true,
+							},
+							uischema.StatItem{
+								Value:    "99%",
+								Label:    "Qoniqish",
+								Icon:     "star",
+								Gradient: true,
+							},
+						},
+					},
+				},
+			},
+			uischema.SizedBox{
+				Height: 20,
+			},
+		},
+	}
+}
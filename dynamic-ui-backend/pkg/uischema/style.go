@@ -0,0 +1,147 @@
+package uischema
+
+import "encoding/json"
+
+type MainAxisAlignment string
+
+const (
+	MainAxisStart        MainAxisAlignment = "start"
+	MainAxisCenter       MainAxisAlignment = "center"
+	MainAxisEnd          MainAxisAlignment = "end"
+	MainAxisSpaceBetween MainAxisAlignment = "space_between"
+	MainAxisSpaceAround  MainAxisAlignment = "space_around"
+	MainAxisSpaceEvenly  MainAxisAlignment = "space_evenly"
+)
+
+type CrossAxisAlignment string
+
+const (
+	CrossAxisStart   CrossAxisAlignment = "start"
+	CrossAxisCenter  CrossAxisAlignment = "center"
+	CrossAxisEnd     CrossAxisAlignment = "end"
+	CrossAxisStretch CrossAxisAlignment = "stretch"
+)
+
+type FontWeight string
+
+const (
+	FontWeightNormal FontWeight = "normal"
+	FontWeightMedium FontWeight = "medium"
+	FontWeightBold   FontWeight = "bold"
+)
+
+type TextAlign string
+
+const (
+	TextAlignLeft   TextAlign = "left"
+	TextAlignCenter TextAlign = "center"
+	TextAlignRight  TextAlign = "right"
+)
+
+// Alignment names a Flutter Alignment used for gradient begin/end.
+type Alignment string
+
+const (
+	TopLeft      Alignment = "topLeft"
+	TopCenter    Alignment = "topCenter"
+	TopRight     Alignment = "topRight"
+	CenterLeft   Alignment = "centerLeft"
+	CenterRight  Alignment = "centerRight"
+	BottomLeft   Alignment = "bottomLeft"
+	BottomCenter Alignment = "bottomCenter"
+	BottomRight  Alignment = "bottomRight"
+)
+
+// EdgeInsets serializes to the object form of padding/margin, or to the
+// plain number form when built with Uniform. Use All, Uniform, Symmetric or
+// LTRB to build one.
+type EdgeInsets struct {
+	All    *float64 `json:"all,omitempty"`
+	Left   *float64 `json:"left,omitempty"`
+	Top    *float64 `json:"top,omitempty"`
+	Right  *float64 `json:"right,omitempty"`
+	Bottom *float64 `json:"bottom,omitempty"`
+
+	uniform *float64
+}
+
+func (e EdgeInsets) MarshalJSON() ([]byte, error) {
+	if e.uniform != nil {
+		return json.Marshal(*e.uniform)
+	}
+	type alias EdgeInsets
+	return json.Marshal(alias(e))
+}
+
+func All(v float64) *EdgeInsets {
+	return &EdgeInsets{All: &v}
+}
+
+// Uniform is the same inset on every side, written as a plain number
+// ("padding": 14).
+func Uniform(v float64) *EdgeInsets {
+	return &EdgeInsets{uniform: &v}
+}
+
+func LTRB(left, top, right, bottom float64) *EdgeInsets {
+	return &EdgeInsets{Left: &left, Top: &top, Right: &right, Bottom: &bottom}
+}
+
+func Symmetric(horizontal, vertical float64) *EdgeInsets {
+	return LTRB(horizontal, vertical, horizontal, vertical)
+}
+
+type Gradient struct {
+	Colors []string  `json:"colors"`
+	Begin  Alignment `json:"begin,omitempty"`
+	End    Alignment `json:"end,omitempty"`
+}
+
+type Offset struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type Shadow struct {
+	Color      string  `json:"color"`
+	BlurRadius float64 `json:"blur_radius"`
+	Offset     Offset  `json:"offset"`
+}
+
+type Border struct {
+	Color string  `json:"color"`
+	Width float64 `json:"width"`
+}
+
+type Decoration struct {
+	BackgroundColor    string    `json:"background_color,omitempty"`
+	BackgroundGradient *Gradient `json:"background_gradient,omitempty"`
+	BorderRadius       float64   `json:"border_radius,omitempty"`
+	Border             *Border   `json:"border,omitempty"`
+	Shadow             *Shadow   `json:"shadow,omitempty"`
+}
+
+// Animation plays when the widget appears. Delay is a pointer so that an
+// explicit zero delay, as in staggered sequences, is kept.
+type Animation struct {
+	Type     string `json:"type"`
+	Duration int    `json:"duration,omitempty"`
+	Delay    *int   `json:"delay,omitempty"`
+	Repeat   bool   `json:"repeat,omitempty"`
+}
+
+type TextStyle struct {
+	FontSize   float64    `json:"font_size,omitempty"`
+	FontWeight FontWeight `json:"font_weight,omitempty"`
+	Color      string     `json:"color,omitempty"`
+	Gradient   *Gradient  `json:"gradient,omitempty"`
+	TextAlign  TextAlign  `json:"text_align,omitempty"`
+	Height     float64    `json:"height,omitempty"`
+}
+
+// VisibleIf hides the widget unless a feature flag has the given value
+// (true when Equals is nil).
+type VisibleIf struct {
+	Flag   string      `json:"flag"`
+	Equals interface{} `json:"equals,omitempty"`
+}
@@ -0,0 +1,320 @@
+package uischema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Widget is any node that can appear in a screen's widget tree.
+type Widget interface {
+	WidgetType() string
+}
+
+// Common holds the properties every widget accepts. Embed it in a widget
+// literal: uischema.Text{Common: uischema.Common{ID: "title"}, ...}.
+type Common struct {
+	ID            string      `json:"id,omitempty"`
+	Margin        *EdgeInsets `json:"margin,omitempty"`
+	Padding       *EdgeInsets `json:"padding,omitempty"`
+	Animation     *Animation  `json:"animation,omitempty"`
+	VisibleIf     *VisibleIf  `json:"visible_if,omitempty"`
+	SemanticLabel string      `json:"semantic_label,omitempty"`
+}
+
+// Layout widgets
+
+type Container struct {
+	Common
+	Decoration *Decoration `json:"decoration,omitempty"`
+	Children   []Widget    `json:"children,omitempty"`
+}
+
+type Row struct {
+	Common
+	MainAxisAlignment  MainAxisAlignment  `json:"main_axis_alignment,omitempty"`
+	CrossAxisAlignment CrossAxisAlignment `json:"cross_axis_alignment,omitempty"`
+	Flex               int                `json:"flex,omitempty"`
+	Children           []Widget           `json:"children"`
+}
+
+type Column struct {
+	Common
+	MainAxisAlignment  MainAxisAlignment  `json:"main_axis_alignment,omitempty"`
+	CrossAxisAlignment CrossAxisAlignment `json:"cross_axis_alignment,omitempty"`
+	Flex               int                `json:"flex,omitempty"`
+	Children           []Widget           `json:"children"`
+}
+
+type SizedBox struct {
+	Common
+	Width  float64 `json:"width,omitempty"`
+	Height float64 `json:"height,omitempty"`
+}
+
+// Content widgets
+
+type Text struct {
+	Common
+	Content string     `json:"content"`
+	Style   *TextStyle `json:"style,omitempty"`
+}
+
+type SectionHeader struct {
+	Common
+	Title string `json:"title"`
+}
+
+type IconWidget struct {
+	Common
+	Icon  string  `json:"icon"`
+	Size  float64 `json:"size,omitempty"`
+	Color string  `json:"color,omitempty"`
+}
+
+type Lottie struct {
+	Common
+	Asset  string `json:"asset"`
+	BoxFit string `json:"box_fit,omitempty"`
+	Repeat bool   `json:"repeat,omitempty"`
+}
+
+type FeatureItem struct {
+	Common
+	Icon        string `json:"icon"`
+	IconColor   string `json:"icon_color,omitempty"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Badge       string `json:"badge,omitempty"`
+	BadgeColor  string `json:"badge_color,omitempty"`
+}
+
+type StatItem struct {
+	Common
+	Value    string `json:"value"`
+	Label    string `json:"label"`
+	Icon     string `json:"icon,omitempty"`
+	Gradient bool   `json:"gradient,omitempty"`
+}
+
+type Button struct {
+	Common
+	Text               string     `json:"text"`
+	Icon               string     `json:"icon,omitempty"`
+	Height             float64    `json:"height,omitempty"`
+	BorderRadius       float64    `json:"border_radius,omitempty"`
+	BackgroundColor    string     `json:"background_color,omitempty"`
+	BackgroundGradient *Gradient  `json:"background_gradient,omitempty"`
+	TextColor          string     `json:"text_color,omitempty"`
+	FontSize           float64    `json:"font_size,omitempty"`
+	FontWeight         FontWeight `json:"font_weight,omitempty"`
+	Shadow             *Shadow    `json:"shadow,omitempty"`
+	Action             Action     `json:"action,omitempty"`
+}
+
+// Input widgets
+
+// FieldValidation is checked by the server when a form is submitted. Min
+// and Max bound the text length, the rating or the number of selected
+// options, depending on the widget.
+type FieldValidation struct {
+	Required bool     `json:"required,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	Options  []string `json:"options,omitempty"`
+	Message  string   `json:"message,omitempty"`
+}
+
+type SurveyOption struct {
+	Icon  string `json:"icon,omitempty"`
+	Text  string `json:"text"`
+	Value string `json:"value"`
+}
+
+type SurveyGroup struct {
+	Common
+	StateKey        string           `json:"state_key"`
+	SingleSelection bool             `json:"single_selection,omitempty"`
+	Options         []SurveyOption   `json:"options"`
+	Validation      *FieldValidation `json:"validation,omitempty"`
+}
+
+type RatingBar struct {
+	Common
+	StateKey      string           `json:"state_key"`
+	MaxRating     int              `json:"max_rating"`
+	InitialRating int              `json:"initial_rating"`
+	IconSize      float64          `json:"icon_size,omitempty"`
+	ActiveColor   string           `json:"active_color,omitempty"`
+	InactiveColor string           `json:"inactive_color,omitempty"`
+	Validation    *FieldValidation `json:"validation,omitempty"`
+}
+
+type TextField struct {
+	Common
+	ControllerKey      string           `json:"controller_key,omitempty"`
+	StateKey           string           `json:"state_key"`
+	Hint               string           `json:"hint,omitempty"`
+	MaxLines           int              `json:"max_lines,omitempty"`
+	MaxLength          int              `json:"max_length,omitempty"`
+	BackgroundColor    string           `json:"background_color,omitempty"`
+	TextColor          string           `json:"text_color,omitempty"`
+	HintColor          string           `json:"hint_color,omitempty"`
+	BorderColor        string           `json:"border_color,omitempty"`
+	FocusedBorderColor string           `json:"focused_border_color,omitempty"`
+	Validation         *FieldValidation `json:"validation,omitempty"`
+}
+
+// Native widgets: rendered entirely by the app, configured by a few fields.
+
+type SearchCategoryCarousel struct {
+	Common
+}
+
+type CategoryGrid struct {
+	Common
+	Decoration *Decoration `json:"decoration,omitempty"`
+}
+
+type BrandsCarousel struct {
+	Common
+}
+
+type AdsCell struct {
+	Common
+	AdsType int `json:"ads_type"`
+}
+
+type AdvisedGoodsGrid struct {
+	Common
+	StateKey        string `json:"state_key"`
+	LoadingStateKey string `json:"loading_state_key,omitempty"`
+	UseStatic       bool   `json:"use_static"`
+}
+
+type PlatformGoodsMasonry struct {
+	Common
+	StateKey         string  `json:"state_key"`
+	CrossAxisCount   int     `json:"cross_axis_count"`
+	MainAxisSpacing  float64 `json:"main_axis_spacing,omitempty"`
+	CrossAxisSpacing float64 `json:"cross_axis_spacing,omitempty"`
+	ShowEmptyState   bool    `json:"show_empty_state,omitempty"`
+	ShowShimmer      bool    `json:"show_shimmer,omitempty"`
+}
+
+type LoadMoreButton struct {
+	Common
+	Action Action `json:"action"`
+}
+
+type DraggableAIFab struct {
+	Common
+	Action Action `json:"action"`
+}
+
+func (Container) WidgetType() string              { return "container" }
+func (Row) WidgetType() string                    { return "row" }
+func (Column) WidgetType() string                 { return "column" }
+func (SizedBox) WidgetType() string               { return "sized_box" }
+func (Text) WidgetType() string                   { return "text" }
+func (SectionHeader) WidgetType() string          { return "section_header" }
+func (IconWidget) WidgetType() string             { return "icon_widget" }
+func (Lottie) WidgetType() string                 { return "lottie" }
+func (FeatureItem) WidgetType() string            { return "feature_item" }
+func (StatItem) WidgetType() string               { return "stat_item" }
+func (Button) WidgetType() string                 { return "button" }
+func (SurveyGroup) WidgetType() string            { return "survey_group" }
+func (RatingBar) WidgetType() string              { return "rating_bar" }
+func (TextField) WidgetType() string              { return "text_field" }
+func (SearchCategoryCarousel) WidgetType() string { return "search_category_carousel" }
+func (CategoryGrid) WidgetType() string           { return "category_grid" }
+func (BrandsCarousel) WidgetType() string         { return "brands_carousel" }
+func (AdsCell) WidgetType() string                { return "ads_cell" }
+func (AdvisedGoodsGrid) WidgetType() string       { return "advised_goods_grid" }
+func (PlatformGoodsMasonry) WidgetType() string   { return "platform_goods_masonry" }
+func (LoadMoreButton) WidgetType() string         { return "load_more_button" }
+func (DraggableAIFab) WidgetType() string         { return "draggable_ai_fab" }
+
+// Each widget and action marshals through withType, which prepends its
+// "type".
+
+func (w Container) MarshalJSON() ([]byte, error)              { return withType(w) }
+func (w Row) MarshalJSON() ([]byte, error)                    { return withType(w) }
+func (w Column) MarshalJSON() ([]byte, error)                 { return withType(w) }
+func (w SizedBox) MarshalJSON() ([]byte, error)               { return withType(w) }
+func (w Text) MarshalJSON() ([]byte, error)                   { return withType(w) }
+func (w SectionHeader) MarshalJSON() ([]byte, error)          { return withType(w) }
+func (w IconWidget) MarshalJSON() ([]byte, error)             { return withType(w) }
+func (w Lottie) MarshalJSON() ([]byte, error)                 { return withType(w) }
+func (w FeatureItem) MarshalJSON() ([]byte, error)            { return withType(w) }
+func (w StatItem) MarshalJSON() ([]byte, error)               { return withType(w) }
+func (w Button) MarshalJSON() ([]byte, error)                 { return withType(w) }
+func (w SurveyGroup) MarshalJSON() ([]byte, error)            { return withType(w) }
+func (w RatingBar) MarshalJSON() ([]byte, error)              { return withType(w) }
+func (w TextField) MarshalJSON() ([]byte, error)              { return withType(w) }
+func (w SearchCategoryCarousel) MarshalJSON() ([]byte, error) { return withType(w) }
+func (w CategoryGrid) MarshalJSON() ([]byte, error)           { return withType(w) }
+func (w BrandsCarousel) MarshalJSON() ([]byte, error)         { return withType(w) }
+func (w AdsCell) MarshalJSON() ([]byte, error)                { return withType(w) }
+func (w AdvisedGoodsGrid) MarshalJSON() ([]byte, error)       { return withType(w) }
+func (w PlatformGoodsMasonry) MarshalJSON() ([]byte, error)   { return withType(w) }
+func (w LoadMoreButton) MarshalJSON() ([]byte, error)         { return withType(w) }
+func (w DraggableAIFab) MarshalJSON() ([]byte, error)         { return withType(w) }
+
+// withType marshals a widget or action with its "type" first. The value is
+// encoded as a method-less copy of its struct type so its own MarshalJSON is
+// not called again.
+func withType(v interface{}) ([]byte, error) {
+	var typ string
+	switch t := v.(type) {
+	case Widget:
+		typ = t.WidgetType()
+	case Action:
+		typ = t.ActionType()
+	default:
+		return nil, fmt.Errorf("uischema: %T is neither a widget nor an action", v)
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(plain(v)); err != nil {
+		return nil, err
+	}
+	data := bytes.TrimSpace(out.Bytes())
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("uischema: %s did not marshal to an object", typ)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"type":`)
+	typeJSON, _ := json.Marshal(typ)
+	buf.Write(typeJSON)
+	if len(bytes.TrimSpace(data[1:len(data)-1])) > 0 {
+		buf.WriteByte(',')
+	}
+	buf.Write(data[1:])
+	return buf.Bytes(), nil
+}
+
+var plainTypes sync.Map // reflect.Type -> reflect.Type
+
+// plain converts a struct value to an unnamed struct type with the same
+// fields, which has no methods.
+func plain(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	t := rv.Type()
+	if pt, ok := plainTypes.Load(t); ok {
+		return rv.Convert(pt.(reflect.Type)).Interface()
+	}
+	fields := make([]reflect.StructField, t.NumField())
+	for i := range fields {
+		fields[i] = t.Field(i)
+	}
+	pt := reflect.StructOf(fields)
+	plainTypes.Store(t, pt)
+	return rv.Convert(pt).Interface()
+}